@@ -3,6 +3,7 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"io"
 	db "main/db/sqlc"
 	"main/pkg/middlewares"
 	"main/token"
@@ -81,3 +82,75 @@ func (server *Server) getAccounts(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "Get accounts list successfully", "data": accounts})
 }
+
+type CloseAccountParams struct {
+	SweepToAccountId *int64 `json:"sweep_to_account_id" binding:"omitempty,gte=1"`
+}
+
+// closeAccount closes an account of the user, a balance left in it is moved
+// to sweep_to_account_id first.
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var uri GetAccountParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req CloseAccountParams
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	authPayload := ctx.MustGet(middlewares.AuthorizationPayloadKey).(*token.Payload)
+
+	acc, ok := server.checkValidAccount(ctx, uri.ID)
+	if !ok {
+		return
+	}
+	if acc.Owner != int64(authPayload.UserID) {
+		err := errors.New("account doesn't belong to that user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	arg := db.CloseAccountTxParams{AccountID: acc.ID}
+	if req.SweepToAccountId != nil && acc.Balance > 0 {
+		if *req.SweepToAccountId == acc.ID {
+			err := errors.New("sweep_to_account_id must be different from the closed account")
+			ctx.Error(util.NewBadRequestError(err, err.Error()))
+			return
+		}
+		sweepAcc, ok := server.checkValidAccount(ctx, *req.SweepToAccountId)
+		if !ok {
+			return
+		}
+		if sweepAcc.Owner != acc.Owner {
+			err := errors.New("sweep_to_account_id must be another account of the same user")
+			ctx.Error(util.NewBadRequestError(err, err.Error()))
+			return
+		}
+		arg.Sweep = &db.TransferTxParams{
+			FromAccountId: acc.ID,
+			ToAccountId:   sweepAcc.ID,
+			Amount:        acc.Balance,
+		}
+		if sweepAcc.Currency != acc.Currency && !server.convertTransfer(ctx, arg.Sweep, acc.Currency, sweepAcc.Currency) {
+			return
+		}
+	}
+
+	result, err := server.Store.CloseAccountTx(ctx, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrAccountNotEmpty):
+			ctx.Error(util.NewAccountNotEmptyError(err, err.Error()))
+		case errors.Is(err, db.ErrAccountFrozen):
+			ctx.Error(util.NewAccountFrozenError(err, err.Error()))
+		case errors.Is(err, db.ErrAccountClosed):
+			ctx.Error(util.NewAccountClosedError(err, err.Error()))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"status": "Close account successfully", "data": result})
+}
@@ -3,26 +3,35 @@ package api
 import (
 	"fmt"
 	db "main/db/sqlc"
+	"main/pkg/authz"
+	"main/pkg/exchange"
 	"main/pkg/middlewares"
+	"main/pkg/policy"
+	"main/pkg/throttle"
 	"main/token"
 	"main/util"
+	"main/worker"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Server struct {
-	Config     util.Config
-	TokenMaker token.Maker
-	Store      db.Store
-	Router     *gin.Engine
+	Config          util.Config
+	TokenMaker      token.Maker
+	Store           db.Store
+	RateProvider    exchange.ExchangeRateProvider
+	TaskDistributor worker.TaskDistributor
+	LoginThrottle   *throttle.LoginThrottle
+	Authorizer      *authz.Authorizer
+	Router          *gin.Engine
 }
 
-func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, tokenMaker token.Maker, authorizer *authz.Authorizer) (*Server, error) {
+	rateProvider, err := exchange.NewRateProvider(config)
 	if err != nil {
-		return nil, fmt.Errorf("cannot create token maker: %w", err)
+		return nil, fmt.Errorf("cannot create exchange rate provider: %w", err)
 	}
-	server := Server{Store: store, TokenMaker: tokenMaker, Config: config}
+	server := Server{Store: store, TokenMaker: tokenMaker, Config: config, RateProvider: rateProvider, TaskDistributor: taskDistributor, LoginThrottle: throttle.NewLoginThrottle(store, config), Authorizer: authorizer}
 	server.SetupRouter()
 
 	return &server, nil
@@ -30,25 +39,28 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 func (server *Server) SetupRouter() {
 	router := gin.Default()
 	router.Use(middlewares.GlobalErrorHandler())
-	err := router.SetTrustedProxies(nil)
+	err := router.SetTrustedProxies(server.Config.TrustedProxies)
 	if err != nil {
 		panic(err)
 	}
 
 	router.POST("/login", server.loginUser)
+	router.POST("/login/mfa", server.loginUserMFA)
 	router.POST("/refresh_token", server.refreshToken)
 
-	privateRouter := router.Group("/").Use(middlewares.AuthMiddleware(server.TokenMaker))
+	privateRouter := router.Group("/").Use(middlewares.AuthMiddleware(server.TokenMaker, server.Store))
+	emailPolicy := policy.NewEmailVerification(server.Store)
 
-	privateRouter.POST("/users", server.createUser)
-	privateRouter.GET("/users/:id", server.getUsertById)
-	privateRouter.PUT("/users/update-me", server.updateUser)
+	privateRouter.POST("/users", middlewares.RequirePermission(server.Authorizer, authz.PermissionUsersWrite), server.createUser)
+	privateRouter.GET("/users/:id", middlewares.RequirePermission(server.Authorizer, authz.PermissionUsersRead), server.getUsertById)
+	privateRouter.PUT("/users/update-me", middlewares.RequirePermission(server.Authorizer, authz.PermissionUsersWrite), middlewares.EmailVerificationMiddleware(emailPolicy, policy.ActionChangeEmail), server.updateUser)
 
-	privateRouter.POST("/accounts", server.createAccount)
-	privateRouter.GET("/accounts/:id", server.getAccountById)
-	privateRouter.GET("/accounts", server.getAccounts)
+	privateRouter.POST("/accounts", middlewares.RequirePermission(server.Authorizer, authz.PermissionAccountsCreate), middlewares.EmailVerificationMiddleware(emailPolicy, policy.ActionCreateAccount), server.createAccount)
+	privateRouter.GET("/accounts/:id", middlewares.RequirePermission(server.Authorizer, authz.PermissionAccountsRead), server.getAccountById)
+	privateRouter.GET("/accounts", middlewares.RequirePermission(server.Authorizer, authz.PermissionAccountsRead), server.getAccounts)
+	privateRouter.POST("/accounts/:id/close", middlewares.RequirePermission(server.Authorizer, authz.PermissionAccountsClose), server.closeAccount)
 
-	privateRouter.POST("/transfer", server.transferMoney)
+	privateRouter.POST("/transfer", middlewares.RequirePermission(server.Authorizer, authz.PermissionTransfersCreate), middlewares.EmailVerificationMiddleware(emailPolicy, policy.ActionTransfer), server.transferMoney)
 
 	server.Router = router
 }
@@ -2,17 +2,25 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	db "main/db/sqlc"
+	"main/pkg/revocation"
+	"main/token"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+var errIncorrectSessionUser = errors.New("incorrect session user")
+
+// refreshToken exchanges a refresh token for a new access token and a new
+// refresh token, the presented one can't be used again.
 func (server *Server) refreshToken(ctx *gin.Context) {
 	var req RefreshTokenRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -24,7 +32,11 @@ func (server *Server) refreshToken(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
-	session, err := server.Store.GetSession(ctx, payload.ID)
+	if payload.Type != token.TokenTypeRefresh {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(token.ErrWrongTokenType))
+		return
+	}
+	user, err := server.Store.GetUser(ctx, int64(payload.UserID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -33,36 +45,70 @@ func (server *Server) refreshToken(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-
-	if session.IsBlocked {
-		err := fmt.Errorf("blocked session")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	if session.UserID != int64(payload.UserID) {
-		err := fmt.Errorf("incorrect session user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if payload.IssuedAt.Before(user.PasswordChangedAt) {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(revocation.ErrTokenRevoked))
 		return
 	}
-
-	if session.RefreshToken != req.RefreshToken {
-		err := fmt.Errorf("mismatched session token")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if user.IsLocked {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrUserLocked))
 		return
 	}
 
-	if time.Now().After(session.ExpiredAt) {
-		err := fmt.Errorf("expired session")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	userId := fmt.Sprintf("%v", payload.UserID)
+	sessionID := uuid.New()
+	var refreshToken string
+	_, err = server.Store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		SessionID:    payload.SessionID,
+		RefreshToken: req.RefreshToken,
+		NewSession: func(session db.Session) (db.CreateSessionParams, error) {
+			if session.UserID != int64(payload.UserID) {
+				return db.CreateSessionParams{}, errIncorrectSessionUser
+			}
+			var refreshPayload *token.Payload
+			var err error
+			refreshToken, refreshPayload, err = server.TokenMaker.CreateToken(
+				token.TokenTypeRefresh,
+				userId,
+				payload.Email,
+				payload.Role,
+				sessionID,
+				server.Config.RefreshTokenDuration,
+			)
+			if err != nil {
+				return db.CreateSessionParams{}, err
+			}
+			return db.CreateSessionParams{
+				ID:           sessionID,
+				UserID:       session.UserID,
+				RefreshToken: refreshToken,
+				UserAgent:    ctx.Request.UserAgent(),
+				ClientIp:     ctx.ClientIP(),
+				IsBlocked:    false,
+				ExpiredAt:    refreshPayload.ExpiredAt,
+			}, nil
+		},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrRefreshTokenReused) || errors.Is(err, db.ErrSessionBlocked) ||
+			errors.Is(err, db.ErrSessionExpired) || errors.Is(err, db.ErrSessionTokenMismatch) ||
+			errors.Is(err, errIncorrectSessionUser) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	userId := fmt.Sprintf("%v", payload.UserID)
 	accessToken, _, err := server.TokenMaker.CreateToken(
+		token.TokenTypeAccess,
 		userId,
 		payload.Email,
 		payload.Role,
+		sessionID,
 		server.Config.TokenDuration,
 	)
 
@@ -71,6 +117,6 @@ func (server *Server) refreshToken(ctx *gin.Context) {
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{
-		"status": "Login successfully", "access_token": accessToken,
+		"status": "Login successfully", "access_token": accessToken, "refresh_token": refreshToken,
 	})
 }
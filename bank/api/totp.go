@@ -0,0 +1,100 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "main/db/sqlc"
+	"main/pkg/totp"
+	"main/util"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// startMfaChallenge answers loginUser for a user with two-factor
+// authentication, the challenge token is exchanged on /login/mfa.
+func (server *Server) startMfaChallenge(ctx *gin.Context, user db.User) {
+	challengeToken, err := util.NewSecretToken(32)
+	if err != nil {
+		ctx.Error(util.NewInternalServerError(err, err.Error()))
+		return
+	}
+	_, err = server.Store.StartMfaChallengeTx(ctx, db.CreateMfaChallengeParams{
+		UserID:    user.UserID,
+		TokenHash: util.HashSecretToken(challengeToken),
+	})
+	if err != nil {
+		ctx.Error(util.NewInternalServerError(err, "error when creating mfa challenge"))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Two-factor authentication required", "mfa_required": true, "mfa_challenge_token": challengeToken,
+	})
+}
+
+type LoginUserMFARequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required_without=RecoveryCode,excluded_with=RecoveryCode"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+func (server *Server) loginUserMFA(ctx *gin.Context) {
+	var req LoginUserMFARequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(util.NewValidationError(err, "invalid request body"))
+		return
+	}
+
+	tokenHash := util.HashSecretToken(req.ChallengeToken)
+	challengeUser, err := server.Store.GetUserByMfaChallenge(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("login challenge not found")))
+			return
+		}
+		ctx.Error(util.NewInternalServerError(err, "error when getting mfa challenge"))
+		return
+	}
+	if !server.checkLoginThrottle(ctx, challengeUser.Email) {
+		return
+	}
+
+	arg := db.CompleteMfaChallengeTxParams{
+		TokenHash: tokenHash,
+		VerifyCode: func(credential db.TotpCredential) (int64, error) {
+			return totp.Validate(req.Code, credential.Secret, time.Now())
+		},
+	}
+	if req.RecoveryCode != "" {
+		arg.RecoveryCodeHash = totp.HashRecoveryCode(req.RecoveryCode)
+	}
+	user, err := server.Store.CompleteMfaChallengeTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("login challenge not found")))
+			return
+		}
+		// wrong codes count like wrong passwords, a new challenge doesn't
+		// give more guesses
+		if errors.Is(err, db.ErrInvalidMfaCode) {
+			if server.recordLoginFailure(ctx, challengeUser.Email, &challengeUser) {
+				ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			}
+			return
+		}
+		if errors.Is(err, db.ErrMfaChallengeUsed) || errors.Is(err, db.ErrMfaChallengeExpired) ||
+			errors.Is(err, db.ErrMfaChallengeTooMany) || errors.Is(err, db.ErrTOTPNotEnabled) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.Error(util.NewInternalServerError(err, "login failed"))
+		return
+	}
+	if user.IsLocked {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrUserLocked))
+		return
+	}
+
+	server.createLoginSession(ctx, user)
+}
@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	db "main/db/sqlc"
+	"main/pkg/exchange"
 	"main/pkg/middlewares"
+	"main/pkg/val"
 	"main/token"
+	"main/util"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 type TransferReqBody struct {
 	FromAccountId int64  `json:"from_account_id" binding:"required"`
 	ToAccountId   int64  `json:"to_account_id" binding:"required"`
@@ -28,33 +33,103 @@ func (server *Server) transferMoney(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(middlewares.AuthorizationPayloadKey).(*token.Payload)
 
-	fromAcc, ok := server.checkValidAccount(ctx, req.FromAccountId, req.Currency)
+	fromAcc, ok := server.checkValidAccount(ctx, req.FromAccountId)
+	if !ok {
+		return
+	}
 	if fromAcc.Owner != int64(authPayload.UserID) {
 		err := errors.New("from account doesn't belong to that user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
-
 	}
-	if !ok {
+	if fromAcc.Currency != req.Currency {
+		err := fmt.Errorf("account [%v] currency mismatch : %s vs %s", req.FromAccountId, fromAcc.Currency, req.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	_, ok = server.checkValidAccount(ctx, req.FromAccountId, req.Currency)
+	toAcc, ok := server.checkValidAccount(ctx, req.ToAccountId)
 	if !ok {
 		return
 	}
-	result, err := server.Store.TransferTx(ctx, db.TransferTxParams{
+	arg := db.TransferTxParams{
 		FromAccountId: req.FromAccountId,
 		ToAccountId:   req.ToAccountId,
 		Amount:        req.Amount,
-	})
+	}
+	if toAcc.Currency != fromAcc.Currency {
+		if !server.convertTransfer(ctx, &arg, fromAcc.Currency, toAcc.Currency) {
+			return
+		}
+	}
+	var result db.TransferTxResult
+	var err error
+	if idempotencyKey := ctx.GetHeader(IdempotencyKeyHeader); idempotencyKey != "" {
+		if err := val.ValidateIdempotencyKey(idempotencyKey); err != nil {
+			ctx.Error(util.NewBadRequestError(err, fmt.Sprintf("invalid %s header", IdempotencyKeyHeader)))
+			return
+		}
+		requestHash, hashErr := util.HashRequest(req)
+		if hashErr != nil {
+			ctx.Error(util.NewInternalServerError(hashErr, hashErr.Error()))
+			return
+		}
+		result, err = server.Store.IdempotentTransferTx(ctx, db.IdempotentTransferTxParams{
+			TransferTxParams: arg,
+			UserID:           int64(authPayload.UserID),
+			IdempotencyKey:   idempotencyKey,
+			RequestHash:      requestHash,
+			Window:           server.Config.IdempotencyKeyTTL,
+		})
+	} else {
+		result, err = server.Store.TransferTx(ctx, arg)
+	}
 	if err != nil {
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			ctx.Error(util.NewConflictError(err, err.Error()))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.Error(util.NewInsufficientFundsError(err, err.Error()))
+			return
+		}
+		if errors.Is(err, db.ErrAccountFrozen) {
+			ctx.Error(util.NewAccountFrozenError(err, err.Error()))
+			return
+		}
+		if errors.Is(err, db.ErrAccountClosed) {
+			ctx.Error(util.NewAccountClosedError(err, err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"status": "Create transfer successfully", "data": result})
 }
 
-func (server *Server) checkValidAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+// convertTransfer prices the transfer in the destination account's currency,
+// it writes the error response and returns false when that's not possible.
+func (server *Server) convertTransfer(ctx *gin.Context, arg *db.TransferTxParams, from string, to string) bool {
+	rate, err := server.RateProvider.GetRate(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, exchange.ErrRateNotFound) {
+			ctx.Error(util.NewBadRequestError(err, err.Error()))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	conversion := exchange.Convert(arg.Amount, from, to, rate)
+	if conversion.ConvertedAmount <= 0 {
+		err := fmt.Errorf("amount is too small to convert from %s to %s", from, to)
+		ctx.Error(util.NewBadRequestError(err, err.Error()))
+		return false
+	}
+	arg.ExchangeRate = conversion.RateString()
+	arg.ConvertedAmount = conversion.ConvertedAmount
+	return true
+}
+
+func (server *Server) checkValidAccount(ctx *gin.Context, accountID int64) (db.Account, bool) {
 	acc, err := server.Store.GetAccount(ctx, accountID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -64,11 +139,5 @@ func (server *Server) checkValidAccount(ctx *gin.Context, accountID int64, curre
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return acc, false
 	}
-
-	if acc.Currency != currency {
-		err := fmt.Errorf("account [%v] currency mismatch : %s vs %s", accountID, acc.Currency, currency)
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return acc, false
-	}
 	return acc, true
 }
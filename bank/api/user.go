@@ -2,17 +2,24 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	db "main/db/sqlc"
 	"main/pkg/middlewares"
+	"main/pkg/throttle"
 	"main/token"
 	"main/util"
+	"main/worker"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 )
 
+var errIncorrectCredentials = errors.New("incorrect email or password")
+
 type CreateUserRequest struct {
 	FullName string `json:"fullname" binding:"required"`
 	Email    string `json:"email" binding:"required"`
@@ -77,29 +84,107 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		ctx.Error(util.NewValidationError(err, "invalid request body"))
 		return
 	}
+	if !server.checkLoginThrottle(ctx, req.Email) {
+		return
+	}
 	user, err := server.Store.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.Error(util.NewNotFoundError(err, "doesn't have user with this email"))
+			util.VerifyDummyPassword(req.Password)
+			server.failLogin(ctx, req.Email, nil)
 			return
 		}
 		ctx.Error(util.NewInternalServerError(err, err.Error()))
 		return
 	}
+	// a locked user gets the same answer as a wrong password, before the
+	// password is checked, so it can't be used to confirm a password
+	if user.IsLocked {
+		util.VerifyDummyPassword(req.Password)
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errIncorrectCredentials))
+		return
+	}
 
 	err = util.VerifyPassword(req.Password, user.HashedPassword)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		server.failLogin(ctx, req.Email, &user)
+		return
+	}
+	credential, err := server.Store.GetTotpCredential(ctx, user.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		ctx.Error(util.NewInternalServerError(err, "error when getting totp credential"))
+		return
+	}
+	if err == nil && credential.IsEnabled {
+		server.startMfaChallenge(ctx, user)
+		return
+	}
+	server.createLoginSession(ctx, user)
+}
+
+// checkLoginThrottle refuses a login step while the email or the client ip
+// is locked out, it writes the error response and returns false then.
+func (server *Server) checkLoginThrottle(ctx *gin.Context, email string) bool {
+	if err := server.LoginThrottle.Check(ctx, email, ctx.ClientIP()); err != nil {
+		var lockedErr *throttle.LockedError
+		if errors.As(err, &lockedErr) {
+			ctx.Error(util.NewTooManyRequestsError(err, err.Error()))
+			return false
+		}
+		ctx.Error(util.NewInternalServerError(err, err.Error()))
+		return false
+	}
+	return true
+}
+
+// failLogin records a wrong email or password and answers the same for
+// both, so loginUser doesn't tell which emails have an account.
+func (server *Server) failLogin(ctx *gin.Context, email string, user *db.User) {
+	if !server.recordLoginFailure(ctx, email, user) {
 		return
 	}
+	ctx.JSON(http.StatusUnauthorized, errorResponse(errIncorrectCredentials))
+}
+
+// recordLoginFailure counts a wrong password or two-factor code against the
+// email and the client ip, it writes the error response and returns false
+// when that's not possible.
+func (server *Server) recordLoginFailure(ctx *gin.Context, email string, user *db.User) bool {
+	lockedOut, err := server.LoginThrottle.Fail(ctx, email, ctx.ClientIP())
+	if err != nil {
+		ctx.Error(util.NewInternalServerError(err, err.Error()))
+		return false
+	}
+	if lockedOut && user != nil {
+		err = server.TaskDistributor.DistributeTaskSendLoginLockoutNotice(ctx, &worker.PayloadSendLoginLockoutNotice{
+			UserID:      user.UserID,
+			ClientIp:    ctx.ClientIP(),
+			LockedUntil: time.Now().Add(server.Config.LoginLockoutDuration).Format(time.RFC1123),
+		}, asynq.MaxRetry(10))
+		if err != nil {
+			ctx.Error(util.NewInternalServerError(err, "error when distribute task"))
+			return false
+		}
+	}
+	return true
+}
 
-	accessToken, _, err := server.TokenMaker.CreateToken(strconv.FormatInt(user.UserID, 10), user.Email, user.Role, server.Config.TokenDuration)
+// createLoginSession issues the access and refresh tokens of a user who
+// completed every login step, only then are the failed logins of the email
+// forgotten.
+func (server *Server) createLoginSession(ctx *gin.Context, user db.User) {
+	if err := server.LoginThrottle.Succeed(ctx, user.Email); err != nil {
+		ctx.Error(util.NewInternalServerError(err, err.Error()))
+		return
+	}
+	sessionID := uuid.New()
+	accessToken, _, err := server.TokenMaker.CreateToken(token.TokenTypeAccess, strconv.FormatInt(user.UserID, 10), user.Email, user.Role, sessionID, server.Config.TokenDuration)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	refreshToken, refreshPayload, err := server.TokenMaker.CreateToken(strconv.FormatInt(user.UserID, 10), user.Email, user.Role, server.Config.RefreshTokenDuration)
+	refreshToken, refreshPayload, err := server.TokenMaker.CreateToken(token.TokenTypeRefresh, strconv.FormatInt(user.UserID, 10), user.Email, user.Role, sessionID, server.Config.RefreshTokenDuration)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -107,13 +192,14 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	_, err = server.Store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
+		ID:           sessionID,
 		UserID:       int64(refreshPayload.UserID),
 		RefreshToken: refreshToken,
 		UserAgent:    "",
 		ClientIp:     "",
 		IsBlocked:    false,
 		ExpiredAt:    refreshPayload.ExpiredAt,
+		FamilyID:     sessionID,
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -137,8 +223,17 @@ func (server *Server) updateUser(ctx *gin.Context) {
 		return
 	}
 	authPayload := ctx.MustGet(middlewares.AuthorizationPayloadKey).(*token.Payload)
+	if req.Email != "" {
+		if err := server.changeEmail(ctx, int64(authPayload.UserID), req.Email); err != nil {
+			if errors.Is(err, db.ErrEmailTaken) || db.ErrorCode(err) == db.UniqueViolation {
+				ctx.Error(util.NewConflictError(err, db.ErrEmailTaken.Error()))
+				return
+			}
+			ctx.Error(util.NewInternalServerError(err, "error when change email"))
+			return
+		}
+	}
 	params := db.UpdateUserParams{
-		Email:    sql.NullString{String: req.Email, Valid: req.Email != ""},
 		FullName: sql.NullString{String: req.FullName, Valid: req.FullName != ""},
 		UserID:   int64(authPayload.UserID),
 	}
@@ -160,3 +255,30 @@ func (server *Server) updateUser(ctx *gin.Context) {
 		"status": "Login successfully", "data": user,
 	})
 }
+
+// changeEmail stages the new email until it's confirmed from the link sent to
+// it, the current address is told about the change.
+func (server *Server) changeEmail(ctx *gin.Context, userID int64, newEmail string) error {
+	_, err := server.Store.ChangeEmailTx(ctx, db.ChangeEmailTxParams{
+		UserID:   userID,
+		NewEmail: newEmail,
+		AfterUpdate: func(user db.User) error {
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+			}
+			err := server.TaskDistributor.DistributeTaskSendVerifyEmail(ctx, &worker.PayloadSendVerifyEmail{
+				UserID: user.UserID,
+				Email:  newEmail,
+			}, opts...)
+			if err != nil {
+				return err
+			}
+			return server.TaskDistributor.DistributeTaskSendEmailChangeNotice(ctx, &worker.PayloadSendEmailChangeNotice{
+				UserID:   user.UserID,
+				OldEmail: user.Email,
+				NewEmail: newEmail,
+			}, opts...)
+		},
+	})
+	return err
+}
@@ -0,0 +1,14 @@
+CREATE TABLE "idempotency_keys" (
+    "user_id" bigint NOT NULL,
+    "idempotency_key" varchar NOT NULL,
+    "request_hash" varchar NOT NULL,
+    "transfer_id" bigint,
+    "response" jsonb NOT NULL DEFAULT '{}',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "expired_at" timestamptz NOT NULL,
+    PRIMARY KEY ("user_id", "idempotency_key")
+);
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
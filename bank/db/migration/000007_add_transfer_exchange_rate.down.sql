@@ -0,0 +1,3 @@
+ALTER TABLE "transfers" DROP COLUMN "converted_amount";
+
+ALTER TABLE "transfers" DROP COLUMN "exchange_rate";
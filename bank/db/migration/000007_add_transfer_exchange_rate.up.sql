@@ -0,0 +1,11 @@
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" numeric(20,10) NOT NULL DEFAULT 1;
+
+ALTER TABLE "transfers" ADD COLUMN "converted_amount" bigint;
+
+UPDATE "transfers" SET "converted_amount" = "amount";
+
+ALTER TABLE "transfers" ALTER COLUMN "converted_amount" SET NOT NULL;
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'units of the destination currency bought by one unit of the source currency';
+
+COMMENT ON COLUMN "transfers"."converted_amount" IS 'amount credited to the destination account in its own currency';
@@ -0,0 +1,17 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+-- entries and their transfer are written in one transaction, so they share
+-- the transaction timestamp
+UPDATE "entries" e
+SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE e."transfer_id" IS NULL
+  AND e."created_at" = t."created_at"
+  AND ((e."account_id" = t."from_account_id" AND e."amount" = -t."amount")
+    OR (e."account_id" = t."to_account_id" AND e."amount" = t."converted_amount"));
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer that produced the entry, null for other movements';
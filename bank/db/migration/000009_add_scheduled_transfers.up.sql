@@ -0,0 +1,59 @@
+CREATE TYPE scheduled_transfer_status AS ENUM ('active', 'paused', 'completed', 'disabled');
+
+CREATE TYPE scheduled_transfer_attempt_status AS ENUM ('pending', 'succeeded', 'failed', 'skipped');
+
+CREATE TABLE "scheduled_transfers" (
+    "id" bigserial PRIMARY KEY,
+    "owner" bigint NOT NULL,
+    "from_account_id" bigint NOT NULL,
+    "to_account_id" bigint NOT NULL,
+    "amount" bigint NOT NULL,
+    "cron_expression" varchar,
+    "interval_seconds" bigint,
+    "next_run_at" timestamptz NOT NULL,
+    "end_at" timestamptz,
+    "status" scheduled_transfer_status NOT NULL DEFAULT 'active',
+    "consecutive_failures" int NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "scheduled_transfers_amount_check" CHECK ("amount" > 0),
+    CONSTRAINT "scheduled_transfers_schedule_check" CHECK (("cron_expression" IS NULL) <> ("interval_seconds" IS NULL))
+);
+
+CREATE TABLE "scheduled_transfer_attempts" (
+    "id" bigserial PRIMARY KEY,
+    "scheduled_transfer_id" bigint NOT NULL,
+    "scheduled_for" timestamptz NOT NULL,
+    "status" scheduled_transfer_attempt_status NOT NULL DEFAULT 'pending',
+    "transfer_id" bigint,
+    "failure_reason" varchar NOT NULL DEFAULT '',
+    "retries" int NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+
+CREATE INDEX ON "scheduled_transfers" ("status", "next_run_at");
+
+CREATE UNIQUE INDEX ON "scheduled_transfer_attempts" ("scheduled_transfer_id", "scheduled_for");
+
+CREATE INDEX ON "scheduled_transfer_attempts" ("status");
+
+COMMENT ON COLUMN "scheduled_transfers"."cron_expression" IS 'standard 5 field cron expression, evaluated in UTC';
+
+COMMENT ON COLUMN "scheduled_transfers"."interval_seconds" IS 'fixed interval between runs, used when cron_expression is null';
+
+COMMENT ON COLUMN "scheduled_transfers"."consecutive_failures" IS 'insufficient funds failures since the last successful run';
+
+COMMENT ON COLUMN "scheduled_transfer_attempts"."retries" IS 'failed tries of a pending attempt for reasons other than insufficient funds';
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("user_id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfer_attempts" ADD FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "scheduled_transfer_attempts" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
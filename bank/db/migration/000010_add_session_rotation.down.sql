@@ -0,0 +1,5 @@
+ALTER TABLE "sessions" DROP COLUMN "is_used";
+
+ALTER TABLE "sessions" DROP COLUMN "parent_id";
+
+ALTER TABLE "sessions" DROP COLUMN "family_id";
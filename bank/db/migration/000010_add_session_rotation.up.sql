@@ -0,0 +1,19 @@
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+ALTER TABLE "sessions" ADD COLUMN "parent_id" uuid;
+
+ALTER TABLE "sessions" ADD COLUMN "is_used" BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE "sessions" ADD FOREIGN KEY ("parent_id") REFERENCES "sessions" ("id");
+
+CREATE INDEX ON "sessions" ("family_id");
+
+COMMENT ON COLUMN "sessions"."family_id" IS 'id of the session created at login, shared by every session rotated from it';
+
+COMMENT ON COLUMN "sessions"."parent_id" IS 'session whose refresh token was exchanged for this one';
+
+COMMENT ON COLUMN "sessions"."is_used" IS 'refresh token was already exchanged, presenting it again blocks the family';
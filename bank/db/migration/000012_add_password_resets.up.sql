@@ -0,0 +1,14 @@
+CREATE TABLE "password_resets" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "token_hash" varchar UNIQUE NOT NULL,
+    "is_used" bool NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "expired_at" timestamptz NOT NULL DEFAULT (now() + interval '30 minutes')
+);
+
+CREATE INDEX ON "password_resets" ("user_id");
+
+COMMENT ON COLUMN "password_resets"."token_hash" IS 'sha256 of the token sent by email, the token itself is never stored';
+
+ALTER TABLE "password_resets" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
@@ -0,0 +1,44 @@
+CREATE TABLE "totp_credentials" (
+    "user_id" bigint PRIMARY KEY,
+    "secret" varchar NOT NULL,
+    "is_enabled" bool NOT NULL DEFAULT false,
+    "last_used_step" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "enabled_at" timestamptz
+);
+
+CREATE TABLE "mfa_recovery_codes" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "code_hash" varchar NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "mfa_challenges" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "token_hash" varchar UNIQUE NOT NULL,
+    "attempts" int NOT NULL DEFAULT 0,
+    "is_used" bool NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "expired_at" timestamptz NOT NULL DEFAULT (now() + interval '5 minutes')
+);
+
+CREATE UNIQUE INDEX ON "mfa_recovery_codes" ("user_id", "code_hash");
+
+CREATE INDEX ON "mfa_challenges" ("user_id");
+
+COMMENT ON COLUMN "totp_credentials"."is_enabled" IS 'false until the user confirms the secret with a first code';
+
+COMMENT ON COLUMN "totp_credentials"."last_used_step" IS 'time step of the last accepted code, codes of that step or older are refused';
+
+COMMENT ON COLUMN "mfa_recovery_codes"."code_hash" IS 'sha256 of the normalized recovery code';
+
+COMMENT ON COLUMN "mfa_challenges"."token_hash" IS 'sha256 of the challenge token returned by LoginUser';
+
+ALTER TABLE "totp_credentials" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "mfa_recovery_codes" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "mfa_challenges" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
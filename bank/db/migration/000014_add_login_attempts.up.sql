@@ -0,0 +1,14 @@
+CREATE TABLE "login_attempts" (
+    "scope" varchar NOT NULL,
+    "identifier" varchar NOT NULL,
+    "failed_count" int NOT NULL DEFAULT 0,
+    "last_failed_at" timestamptz NOT NULL DEFAULT (now()),
+    "locked_until" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("scope", "identifier")
+);
+
+COMMENT ON COLUMN "login_attempts"."scope" IS 'email or ip';
+
+COMMENT ON COLUMN "login_attempts"."identifier" IS 'lowercased email or client ip the failed logins came from';
+
+COMMENT ON COLUMN "login_attempts"."locked_until" IS 'logins for this scope and identifier are refused until then';
@@ -0,0 +1,32 @@
+CREATE TABLE "external_identities" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "issuer" varchar NOT NULL,
+    "subject" varchar NOT NULL,
+    "email" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "oidc_states" (
+    "id" bigserial PRIMARY KEY,
+    "state_hash" varchar UNIQUE NOT NULL,
+    "code_verifier" varchar NOT NULL,
+    "nonce" varchar NOT NULL,
+    "is_used" bool NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "expired_at" timestamptz NOT NULL DEFAULT (now() + interval '10 minutes')
+);
+
+CREATE UNIQUE INDEX ON "external_identities" ("issuer", "subject");
+
+CREATE INDEX ON "external_identities" ("user_id");
+
+COMMENT ON COLUMN "external_identities"."subject" IS 'sub claim of the id token, unique per issuer';
+
+COMMENT ON COLUMN "external_identities"."email" IS 'verified email of the id token when the identity was linked';
+
+COMMENT ON COLUMN "oidc_states"."state_hash" IS 'sha256 of the state sent to the identity provider';
+
+COMMENT ON COLUMN "oidc_states"."code_verifier" IS 'PKCE verifier sent with the authorization code';
+
+ALTER TABLE "external_identities" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
@@ -0,0 +1,21 @@
+CREATE TABLE "api_keys" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "name" varchar NOT NULL,
+    "key_prefix" varchar NOT NULL,
+    "key_hash" varchar UNIQUE NOT NULL,
+    "permissions" varchar[] NOT NULL DEFAULT '{}',
+    "expired_at" timestamptz NOT NULL,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "api_keys" ("user_id");
+
+COMMENT ON COLUMN "api_keys"."key_prefix" IS 'first characters of the key, shown to tell keys apart';
+
+COMMENT ON COLUMN "api_keys"."key_hash" IS 'sha256 of the key, the key itself is only shown once';
+
+COMMENT ON COLUMN "api_keys"."permissions" IS 'what the key may do on behalf of its user';
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
@@ -0,0 +1,28 @@
+CREATE TABLE "role_permissions" (
+    "role" user_role NOT NULL,
+    "permission" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("role", "permission")
+);
+
+COMMENT ON COLUMN "role_permissions"."permission" IS 'resource:action, required by the RPCs through their permission option';
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+    ('guest', 'users:read'),
+    ('user', 'users:read'),
+    ('user', 'users:write'),
+    ('user', 'sessions:manage'),
+    ('user', 'accounts:read'),
+    ('user', 'accounts:create'),
+    ('user', 'transfers:create'),
+    ('user', 'scheduled_transfers:read'),
+    ('user', 'scheduled_transfers:write'),
+    ('admin', 'users:read'),
+    ('admin', 'users:write'),
+    ('admin', 'users:admin'),
+    ('admin', 'sessions:manage'),
+    ('admin', 'accounts:read'),
+    ('admin', 'accounts:create'),
+    ('admin', 'transfers:create'),
+    ('admin', 'scheduled_transfers:read'),
+    ('admin', 'scheduled_transfers:write');
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "audit_logs";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "is_frozen";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "is_locked";
@@ -0,0 +1,25 @@
+ALTER TABLE "users" ADD COLUMN "is_locked" bool NOT NULL DEFAULT false;
+
+ALTER TABLE "accounts" ADD COLUMN "is_frozen" bool NOT NULL DEFAULT false;
+
+CREATE TABLE "audit_logs" (
+    "id" bigserial PRIMARY KEY,
+    "actor_id" bigint NOT NULL,
+    "action" varchar NOT NULL,
+    "target_type" varchar NOT NULL,
+    "target_id" varchar NOT NULL,
+    "details" jsonb NOT NULL DEFAULT '{}',
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_logs" ("actor_id");
+
+CREATE INDEX ON "audit_logs" ("target_type", "target_id");
+
+COMMENT ON COLUMN "users"."is_locked" IS 'set by an admin, a locked user can''t log in';
+
+COMMENT ON COLUMN "accounts"."is_frozen" IS 'set by an admin, no money moves in or out of a frozen account';
+
+COMMENT ON COLUMN "audit_logs"."actor_id" IS 'admin who performed the action';
+
+ALTER TABLE "audit_logs" ADD FOREIGN KEY ("actor_id") REFERENCES "users" ("user_id");
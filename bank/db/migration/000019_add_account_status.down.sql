@@ -0,0 +1,17 @@
+DELETE FROM "role_permissions" WHERE "permission" = 'accounts:close';
+
+DROP INDEX IF EXISTS "accounts_owner_currency_idx";
+
+CREATE UNIQUE INDEX "accounts_owner_currency_idx" ON "accounts" ("owner", "currency");
+
+ALTER TABLE "accounts" ADD COLUMN "is_frozen" bool NOT NULL DEFAULT false;
+
+UPDATE "accounts" SET "is_frozen" = true WHERE "status" = 'frozen';
+
+COMMENT ON COLUMN "accounts"."is_frozen" IS 'set by an admin, no money moves in or out of a frozen account';
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "closed_at";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "status";
+
+DROP TYPE IF EXISTS account_status;
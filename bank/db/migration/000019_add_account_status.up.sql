@@ -0,0 +1,23 @@
+CREATE TYPE account_status AS ENUM ('active', 'frozen', 'closed');
+
+ALTER TABLE "accounts" ADD COLUMN "status" account_status NOT NULL DEFAULT 'active';
+
+ALTER TABLE "accounts" ADD COLUMN "closed_at" timestamptz;
+
+UPDATE "accounts" SET "status" = 'frozen' WHERE "is_frozen";
+
+ALTER TABLE "accounts" DROP COLUMN "is_frozen";
+
+-- a closed account keeps its history, the user can open a new one in the
+-- same currency
+DROP INDEX IF EXISTS "accounts_owner_currency_idx";
+
+CREATE UNIQUE INDEX "accounts_owner_currency_idx" ON "accounts" ("owner", "currency") WHERE "status" <> 'closed';
+
+COMMENT ON COLUMN "accounts"."status" IS 'no money moves in or out of a frozen or closed account, only admins freeze accounts';
+
+COMMENT ON COLUMN "accounts"."closed_at" IS 'set when the owner closes the account, closed accounts are never deleted';
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+    ('user', 'accounts:close'),
+    ('admin', 'accounts:close');
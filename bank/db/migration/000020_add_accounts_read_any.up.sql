@@ -0,0 +1,4 @@
+-- reading the history of accounts owned by others used to be tied to the
+-- admin role in code
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+    ('admin', 'accounts:read_any');
@@ -0,0 +1,5 @@
+CREATE INDEX ON "entries" ("account_id");
+
+DROP INDEX IF EXISTS "entries_account_id_id_idx";
+
+ALTER TABLE "entries" DROP COLUMN "balance_after";
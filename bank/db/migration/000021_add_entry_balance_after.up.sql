@@ -0,0 +1,22 @@
+ALTER TABLE "entries" ADD COLUMN "balance_after" bigint;
+
+-- the newest entry of an account leaves it at its current balance, every
+-- older one at that balance minus the entries written after it
+UPDATE "entries" e
+SET "balance_after" = b."balance_after"
+FROM (
+  SELECT e."id",
+    a."balance" - SUM(e."amount") OVER (PARTITION BY e."account_id" ORDER BY e."id" DESC) + e."amount" AS "balance_after"
+  FROM "entries" e
+  JOIN "accounts" a ON a."id" = e."account_id"
+) b
+WHERE e."id" = b."id";
+
+ALTER TABLE "entries" ALTER COLUMN "balance_after" SET NOT NULL;
+
+COMMENT ON COLUMN "entries"."balance_after" IS 'account balance right after the entry was applied';
+
+-- account history is paged newest first by id
+CREATE INDEX ON "entries" ("account_id", "id");
+
+DROP INDEX IF EXISTS "entries_account_id_idx";
@@ -42,6 +42,184 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AccountStatementTx mocks base method.
+func (m *MockStore) AccountStatementTx(ctx context.Context, arg db.AccountStatementTxParams) (db.AccountStatementTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountStatementTx", ctx, arg)
+	ret0, _ := ret[0].(db.AccountStatementTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountStatementTx indicates an expected call of AccountStatementTx.
+func (mr *MockStoreMockRecorder) AccountStatementTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountStatementTx", reflect.TypeOf((*MockStore)(nil).AccountStatementTx), ctx, arg)
+}
+
+// AdminActionTx mocks base method.
+func (m *MockStore) AdminActionTx(ctx context.Context, arg db.AdminActionTxParams) (db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminActionTx", ctx, arg)
+	ret0, _ := ret[0].(db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminActionTx indicates an expected call of AdminActionTx.
+func (mr *MockStoreMockRecorder) AdminActionTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminActionTx", reflect.TypeOf((*MockStore)(nil).AdminActionTx), ctx, arg)
+}
+
+// ApplyPendingEmail mocks base method.
+func (m *MockStore) ApplyPendingEmail(ctx context.Context, userID int64) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPendingEmail", ctx, userID)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPendingEmail indicates an expected call of ApplyPendingEmail.
+func (mr *MockStoreMockRecorder) ApplyPendingEmail(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPendingEmail", reflect.TypeOf((*MockStore)(nil).ApplyPendingEmail), ctx, userID)
+}
+
+// BlockSessionFamily mocks base method.
+func (m *MockStore) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSessionFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockSessionFamily indicates an expected call of BlockSessionFamily.
+func (mr *MockStoreMockRecorder) BlockSessionFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionFamily", reflect.TypeOf((*MockStore)(nil).BlockSessionFamily), ctx, familyID)
+}
+
+// BlockUserSessions mocks base method.
+func (m *MockStore) BlockUserSessions(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUserSessions", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUserSessions indicates an expected call of BlockUserSessions.
+func (mr *MockStoreMockRecorder) BlockUserSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessions", reflect.TypeOf((*MockStore)(nil).BlockUserSessions), ctx, userID)
+}
+
+// ChangeEmailTx mocks base method.
+func (m *MockStore) ChangeEmailTx(ctx context.Context, arg db.ChangeEmailTxParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmailTx", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeEmailTx indicates an expected call of ChangeEmailTx.
+func (mr *MockStoreMockRecorder) ChangeEmailTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmailTx", reflect.TypeOf((*MockStore)(nil).ChangeEmailTx), ctx, arg)
+}
+
+// ClaimScheduledTransfersTx mocks base method.
+func (m *MockStore) ClaimScheduledTransfersTx(ctx context.Context, arg db.ClaimScheduledTransfersTxParams) ([]db.ScheduledTransferAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimScheduledTransfersTx", ctx, arg)
+	ret0, _ := ret[0].([]db.ScheduledTransferAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimScheduledTransfersTx indicates an expected call of ClaimScheduledTransfersTx.
+func (mr *MockStoreMockRecorder) ClaimScheduledTransfersTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimScheduledTransfersTx", reflect.TypeOf((*MockStore)(nil).ClaimScheduledTransfersTx), ctx, arg)
+}
+
+// CloseAccountTx mocks base method.
+func (m *MockStore) CloseAccountTx(ctx context.Context, arg db.CloseAccountTxParams) (db.CloseAccountTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAccountTx", ctx, arg)
+	ret0, _ := ret[0].(db.CloseAccountTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAccountTx indicates an expected call of CloseAccountTx.
+func (mr *MockStoreMockRecorder) CloseAccountTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAccountTx", reflect.TypeOf((*MockStore)(nil).CloseAccountTx), ctx, arg)
+}
+
+// CompleteMfaChallengeTx mocks base method.
+func (m *MockStore) CompleteMfaChallengeTx(ctx context.Context, arg db.CompleteMfaChallengeTxParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMfaChallengeTx", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMfaChallengeTx indicates an expected call of CompleteMfaChallengeTx.
+func (mr *MockStoreMockRecorder) CompleteMfaChallengeTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMfaChallengeTx", reflect.TypeOf((*MockStore)(nil).CompleteMfaChallengeTx), ctx, arg)
+}
+
+// CompleteScheduledTransferAttempt mocks base method.
+func (m *MockStore) CompleteScheduledTransferAttempt(ctx context.Context, arg db.CompleteScheduledTransferAttemptParams) (db.ScheduledTransferAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteScheduledTransferAttempt", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransferAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteScheduledTransferAttempt indicates an expected call of CompleteScheduledTransferAttempt.
+func (mr *MockStoreMockRecorder) CompleteScheduledTransferAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteScheduledTransferAttempt", reflect.TypeOf((*MockStore)(nil).CompleteScheduledTransferAttempt), ctx, arg)
+}
+
+// CompleteScheduledTransferAttemptTx mocks base method.
+func (m *MockStore) CompleteScheduledTransferAttemptTx(ctx context.Context, arg db.CompleteScheduledTransferAttemptTxParams) (db.ScheduledTransferAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteScheduledTransferAttemptTx", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransferAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteScheduledTransferAttemptTx indicates an expected call of CompleteScheduledTransferAttemptTx.
+func (mr *MockStoreMockRecorder) CompleteScheduledTransferAttemptTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteScheduledTransferAttemptTx", reflect.TypeOf((*MockStore)(nil).CompleteScheduledTransferAttemptTx), ctx, arg)
+}
+
+// CountAccounts mocks base method.
+func (m *MockStore) CountAccounts(ctx context.Context, owner int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccounts", ctx, owner)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccounts indicates an expected call of CountAccounts.
+func (mr *MockStoreMockRecorder) CountAccounts(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccounts", reflect.TypeOf((*MockStore)(nil).CountAccounts), ctx, owner)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +235,36 @@ func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
 }
 
+// CreateApiKey mocks base method.
+func (m *MockStore) CreateApiKey(ctx context.Context, arg db.CreateApiKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiKey", ctx, arg)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockStoreMockRecorder) CreateApiKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockStore)(nil).CreateApiKey), ctx, arg)
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockStore) CreateAuditLog(ctx context.Context, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", ctx, arg)
+	ret0, _ := ret[0].(db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockStoreMockRecorder) CreateAuditLog(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockStore)(nil).CreateAuditLog), ctx, arg)
+}
+
 // CreateEntry mocks base method.
 func (m *MockStore) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
 	m.ctrl.T.Helper()
@@ -69,274 +277,1151 @@ func (m *MockStore) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (
 // CreateEntry indicates an expected call of CreateEntry.
 func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
+}
+
+// CreateExternalIdentity mocks base method.
+func (m *MockStore) CreateExternalIdentity(ctx context.Context, arg db.CreateExternalIdentityParams) (db.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExternalIdentity", ctx, arg)
+	ret0, _ := ret[0].(db.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExternalIdentity indicates an expected call of CreateExternalIdentity.
+func (mr *MockStoreMockRecorder) CreateExternalIdentity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalIdentity", reflect.TypeOf((*MockStore)(nil).CreateExternalIdentity), ctx, arg)
+}
+
+// CreateIdempotencyKey mocks base method.
+func (m *MockStore) CreateIdempotencyKey(ctx context.Context, arg db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockStoreMockRecorder) CreateIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockStore)(nil).CreateIdempotencyKey), ctx, arg)
+}
+
+// CreateMfaChallenge mocks base method.
+func (m *MockStore) CreateMfaChallenge(ctx context.Context, arg db.CreateMfaChallengeParams) (db.MfaChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMfaChallenge", ctx, arg)
+	ret0, _ := ret[0].(db.MfaChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMfaChallenge indicates an expected call of CreateMfaChallenge.
+func (mr *MockStoreMockRecorder) CreateMfaChallenge(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMfaChallenge", reflect.TypeOf((*MockStore)(nil).CreateMfaChallenge), ctx, arg)
+}
+
+// CreateMfaRecoveryCode mocks base method.
+func (m *MockStore) CreateMfaRecoveryCode(ctx context.Context, arg db.CreateMfaRecoveryCodeParams) (db.MfaRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMfaRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(db.MfaRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMfaRecoveryCode indicates an expected call of CreateMfaRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateMfaRecoveryCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMfaRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateMfaRecoveryCode), ctx, arg)
+}
+
+// CreateOidcState mocks base method.
+func (m *MockStore) CreateOidcState(ctx context.Context, arg db.CreateOidcStateParams) (db.OidcState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOidcState", ctx, arg)
+	ret0, _ := ret[0].(db.OidcState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOidcState indicates an expected call of CreateOidcState.
+func (mr *MockStoreMockRecorder) CreateOidcState(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOidcState", reflect.TypeOf((*MockStore)(nil).CreateOidcState), ctx, arg)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockStore) CreatePasswordReset(ctx context.Context, arg db.CreatePasswordResetParams) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", ctx, arg)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockStoreMockRecorder) CreatePasswordReset(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockStore)(nil).CreatePasswordReset), ctx, arg)
+}
+
+// CreateScheduledTransfer mocks base method.
+func (m *MockStore) CreateScheduledTransfer(ctx context.Context, arg db.CreateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransfer indicates an expected call of CreateScheduledTransfer.
+func (mr *MockStoreMockRecorder) CreateScheduledTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransfer", reflect.TypeOf((*MockStore)(nil).CreateScheduledTransfer), ctx, arg)
+}
+
+// CreateScheduledTransferAttempt mocks base method.
+func (m *MockStore) CreateScheduledTransferAttempt(ctx context.Context, arg db.CreateScheduledTransferAttemptParams) (db.ScheduledTransferAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransferAttempt", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransferAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransferAttempt indicates an expected call of CreateScheduledTransferAttempt.
+func (mr *MockStoreMockRecorder) CreateScheduledTransferAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransferAttempt", reflect.TypeOf((*MockStore)(nil).CreateScheduledTransferAttempt), ctx, arg)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+}
+
+// CreateUserTx mocks base method.
+func (m *MockStore) CreateUserTx(ctx context.Context, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserTx indicates an expected call of CreateUserTx.
+func (mr *MockStoreMockRecorder) CreateUserTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), ctx, arg)
+}
+
+// CreateVerifyEmail mocks base method.
+func (m *MockStore) CreateVerifyEmail(ctx context.Context, arg db.CreateVerifyEmailParams) (db.VerifyEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerifyEmail", ctx, arg)
+	ret0, _ := ret[0].(db.VerifyEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVerifyEmail indicates an expected call of CreateVerifyEmail.
+func (mr *MockStoreMockRecorder) CreateVerifyEmail(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmail", reflect.TypeOf((*MockStore)(nil).CreateVerifyEmail), ctx, arg)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockStore) DeleteEntry(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockStoreMockRecorder) DeleteEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), ctx, id)
+}
+
+// DeleteLoginAttempt mocks base method.
+func (m *MockStore) DeleteLoginAttempt(ctx context.Context, arg db.DeleteLoginAttemptParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginAttempt", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginAttempt indicates an expected call of DeleteLoginAttempt.
+func (mr *MockStoreMockRecorder) DeleteLoginAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginAttempt", reflect.TypeOf((*MockStore)(nil).DeleteLoginAttempt), ctx, arg)
+}
+
+// DeleteMfaRecoveryCodes mocks base method.
+func (m *MockStore) DeleteMfaRecoveryCodes(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMfaRecoveryCodes", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMfaRecoveryCodes indicates an expected call of DeleteMfaRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteMfaRecoveryCodes(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMfaRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteMfaRecoveryCodes), ctx, userID)
+}
+
+// DeleteScheduledTransfer mocks base method.
+func (m *MockStore) DeleteScheduledTransfer(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledTransfer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledTransfer indicates an expected call of DeleteScheduledTransfer.
+func (mr *MockStoreMockRecorder) DeleteScheduledTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledTransfer", reflect.TypeOf((*MockStore)(nil).DeleteScheduledTransfer), ctx, id)
+}
+
+// DeleteTransfer mocks base method.
+func (m *MockStore) DeleteTransfer(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransfer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransfer indicates an expected call of DeleteTransfer.
+func (mr *MockStoreMockRecorder) DeleteTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockStore)(nil).DeleteTransfer), ctx, id)
+}
+
+// DisableAccountScheduledTransfers mocks base method.
+func (m *MockStore) DisableAccountScheduledTransfers(ctx context.Context, accountID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableAccountScheduledTransfers", ctx, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableAccountScheduledTransfers indicates an expected call of DisableAccountScheduledTransfers.
+func (mr *MockStoreMockRecorder) DisableAccountScheduledTransfers(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAccountScheduledTransfers", reflect.TypeOf((*MockStore)(nil).DisableAccountScheduledTransfers), ctx, accountID)
+}
+
+// EnableTOTPTx mocks base method.
+func (m *MockStore) EnableTOTPTx(ctx context.Context, arg db.EnableTOTPTxParams) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTPTx", ctx, arg)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTOTPTx indicates an expected call of EnableTOTPTx.
+func (mr *MockStoreMockRecorder) EnableTOTPTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTPTx", reflect.TypeOf((*MockStore)(nil).EnableTOTPTx), ctx, arg)
+}
+
+// EnableTotpCredential mocks base method.
+func (m *MockStore) EnableTotpCredential(ctx context.Context, arg db.EnableTotpCredentialParams) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTotpCredential", ctx, arg)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTotpCredential indicates an expected call of EnableTotpCredential.
+func (mr *MockStoreMockRecorder) EnableTotpCredential(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTotpCredential", reflect.TypeOf((*MockStore)(nil).EnableTotpCredential), ctx, arg)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+}
+
+// GetAccountBalanceAt mocks base method.
+func (m *MockStore) GetAccountBalanceAt(ctx context.Context, arg db.GetAccountBalanceAtParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalanceAt", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalanceAt indicates an expected call of GetAccountBalanceAt.
+func (mr *MockStoreMockRecorder) GetAccountBalanceAt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalanceAt", reflect.TypeOf((*MockStore)(nil).GetAccountBalanceAt), ctx, arg)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+}
+
+// GetApiKeyByHash mocks base method.
+func (m *MockStore) GetApiKeyByHash(ctx context.Context, keyHash string) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeyByHash indicates an expected call of GetApiKeyByHash.
+func (mr *MockStoreMockRecorder) GetApiKeyByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeyByHash", reflect.TypeOf((*MockStore)(nil).GetApiKeyByHash), ctx, keyHash)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+}
+
+// GetExternalIdentity mocks base method.
+func (m *MockStore) GetExternalIdentity(ctx context.Context, arg db.GetExternalIdentityParams) (db.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalIdentity", ctx, arg)
+	ret0, _ := ret[0].(db.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExternalIdentity indicates an expected call of GetExternalIdentity.
+func (mr *MockStoreMockRecorder) GetExternalIdentity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalIdentity", reflect.TypeOf((*MockStore)(nil).GetExternalIdentity), ctx, arg)
+}
+
+// GetIdempotencyKeyForUpdate mocks base method.
+func (m *MockStore) GetIdempotencyKeyForUpdate(ctx context.Context, arg db.GetIdempotencyKeyForUpdateParams) (db.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKeyForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKeyForUpdate indicates an expected call of GetIdempotencyKeyForUpdate.
+func (mr *MockStoreMockRecorder) GetIdempotencyKeyForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKeyForUpdate", reflect.TypeOf((*MockStore)(nil).GetIdempotencyKeyForUpdate), ctx, arg)
+}
+
+// GetLatestVerifyEmail mocks base method.
+func (m *MockStore) GetLatestVerifyEmail(ctx context.Context, userID int64) (db.VerifyEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestVerifyEmail", ctx, userID)
+	ret0, _ := ret[0].(db.VerifyEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestVerifyEmail indicates an expected call of GetLatestVerifyEmail.
+func (mr *MockStoreMockRecorder) GetLatestVerifyEmail(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestVerifyEmail", reflect.TypeOf((*MockStore)(nil).GetLatestVerifyEmail), ctx, userID)
+}
+
+// GetLoginAttempt mocks base method.
+func (m *MockStore) GetLoginAttempt(ctx context.Context, arg db.GetLoginAttemptParams) (db.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempt", ctx, arg)
+	ret0, _ := ret[0].(db.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttempt indicates an expected call of GetLoginAttempt.
+func (mr *MockStoreMockRecorder) GetLoginAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempt", reflect.TypeOf((*MockStore)(nil).GetLoginAttempt), ctx, arg)
+}
+
+// GetMfaChallengeForUpdate mocks base method.
+func (m *MockStore) GetMfaChallengeForUpdate(ctx context.Context, tokenHash string) (db.MfaChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMfaChallengeForUpdate", ctx, tokenHash)
+	ret0, _ := ret[0].(db.MfaChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMfaChallengeForUpdate indicates an expected call of GetMfaChallengeForUpdate.
+func (mr *MockStoreMockRecorder) GetMfaChallengeForUpdate(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMfaChallengeForUpdate", reflect.TypeOf((*MockStore)(nil).GetMfaChallengeForUpdate), ctx, tokenHash)
+}
+
+// GetPasswordResetForUpdate mocks base method.
+func (m *MockStore) GetPasswordResetForUpdate(ctx context.Context, tokenHash string) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetForUpdate", ctx, tokenHash)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetForUpdate indicates an expected call of GetPasswordResetForUpdate.
+func (mr *MockStoreMockRecorder) GetPasswordResetForUpdate(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetForUpdate", reflect.TypeOf((*MockStore)(nil).GetPasswordResetForUpdate), ctx, tokenHash)
+}
+
+// GetScheduledTransfer mocks base method.
+func (m *MockStore) GetScheduledTransfer(ctx context.Context, id int64) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransfer", ctx, id)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransfer indicates an expected call of GetScheduledTransfer.
+func (mr *MockStoreMockRecorder) GetScheduledTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransfer", reflect.TypeOf((*MockStore)(nil).GetScheduledTransfer), ctx, id)
+}
+
+// GetScheduledTransferForUpdate mocks base method.
+func (m *MockStore) GetScheduledTransferForUpdate(ctx context.Context, id int64) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransferForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransferForUpdate indicates an expected call of GetScheduledTransferForUpdate.
+func (mr *MockStoreMockRecorder) GetScheduledTransferForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransferForUpdate", reflect.TypeOf((*MockStore)(nil).GetScheduledTransferForUpdate), ctx, id)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.GetSessionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(db.GetSessionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+}
+
+// GetSessionForUpdate mocks base method.
+func (m *MockStore) GetSessionForUpdate(ctx context.Context, id uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionForUpdate indicates an expected call of GetSessionForUpdate.
+func (mr *MockStoreMockRecorder) GetSessionForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionForUpdate", reflect.TypeOf((*MockStore)(nil).GetSessionForUpdate), ctx, id)
+}
+
+// GetTotpCredential mocks base method.
+func (m *MockStore) GetTotpCredential(ctx context.Context, userID int64) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotpCredential", ctx, userID)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotpCredential indicates an expected call of GetTotpCredential.
+func (mr *MockStoreMockRecorder) GetTotpCredential(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotpCredential", reflect.TypeOf((*MockStore)(nil).GetTotpCredential), ctx, userID)
+}
+
+// GetTotpCredentialForUpdate mocks base method.
+func (m *MockStore) GetTotpCredentialForUpdate(ctx context.Context, userID int64) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotpCredentialForUpdate", ctx, userID)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotpCredentialForUpdate indicates an expected call of GetTotpCredentialForUpdate.
+func (mr *MockStoreMockRecorder) GetTotpCredentialForUpdate(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotpCredentialForUpdate", reflect.TypeOf((*MockStore)(nil).GetTotpCredentialForUpdate), ctx, userID)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(ctx context.Context, userID int64) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, userID)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByMfaChallenge mocks base method.
+func (m *MockStore) GetUserByMfaChallenge(ctx context.Context, tokenHash string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByMfaChallenge", ctx, tokenHash)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByMfaChallenge indicates an expected call of GetUserByMfaChallenge.
+func (mr *MockStoreMockRecorder) GetUserByMfaChallenge(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByMfaChallenge", reflect.TypeOf((*MockStore)(nil).GetUserByMfaChallenge), ctx, tokenHash)
+}
+
+// GetVerifyEmailForUpdate mocks base method.
+func (m *MockStore) GetVerifyEmailForUpdate(ctx context.Context, id int64) (db.VerifyEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifyEmailForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.VerifyEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifyEmailForUpdate indicates an expected call of GetVerifyEmailForUpdate.
+func (mr *MockStoreMockRecorder) GetVerifyEmailForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifyEmailForUpdate", reflect.TypeOf((*MockStore)(nil).GetVerifyEmailForUpdate), ctx, id)
+}
+
+// IdempotentTransferTx mocks base method.
+func (m *MockStore) IdempotentTransferTx(ctx context.Context, arg db.IdempotentTransferTxParams) (db.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IdempotentTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IdempotentTransferTx indicates an expected call of IdempotentTransferTx.
+func (mr *MockStoreMockRecorder) IdempotentTransferTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IdempotentTransferTx", reflect.TypeOf((*MockStore)(nil).IdempotentTransferTx), ctx, arg)
+}
+
+// IncrementMfaChallengeAttempts mocks base method.
+func (m *MockStore) IncrementMfaChallengeAttempts(ctx context.Context, id int64) (db.MfaChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementMfaChallengeAttempts", ctx, id)
+	ret0, _ := ret[0].(db.MfaChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementMfaChallengeAttempts indicates an expected call of IncrementMfaChallengeAttempts.
+func (mr *MockStoreMockRecorder) IncrementMfaChallengeAttempts(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementMfaChallengeAttempts", reflect.TypeOf((*MockStore)(nil).IncrementMfaChallengeAttempts), ctx, id)
+}
+
+// InvalidateMfaChallenges mocks base method.
+func (m *MockStore) InvalidateMfaChallenges(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateMfaChallenges", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateMfaChallenges indicates an expected call of InvalidateMfaChallenges.
+func (mr *MockStoreMockRecorder) InvalidateMfaChallenges(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateMfaChallenges", reflect.TypeOf((*MockStore)(nil).InvalidateMfaChallenges), ctx, userID)
+}
+
+// InvalidatePasswordResets mocks base method.
+func (m *MockStore) InvalidatePasswordResets(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePasswordResets", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePasswordResets indicates an expected call of InvalidatePasswordResets.
+func (mr *MockStoreMockRecorder) InvalidatePasswordResets(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePasswordResets", reflect.TypeOf((*MockStore)(nil).InvalidatePasswordResets), ctx, userID)
+}
+
+// ListAccountEntries mocks base method.
+func (m *MockStore) ListAccountEntries(ctx context.Context, arg db.ListAccountEntriesParams) ([]db.ListAccountEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.ListAccountEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountEntries indicates an expected call of ListAccountEntries.
+func (mr *MockStoreMockRecorder) ListAccountEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountEntries", reflect.TypeOf((*MockStore)(nil).ListAccountEntries), ctx, arg)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+}
+
+// ListActiveSessions mocks base method.
+func (m *MockStore) ListActiveSessions(ctx context.Context, userID int64) ([]db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSessions", ctx, userID)
+	ret0, _ := ret[0].([]db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSessions indicates an expected call of ListActiveSessions.
+func (mr *MockStoreMockRecorder) ListActiveSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSessions", reflect.TypeOf((*MockStore)(nil).ListActiveSessions), ctx, userID)
+}
+
+// ListApiKeys mocks base method.
+func (m *MockStore) ListApiKeys(ctx context.Context, userID int64) ([]db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApiKeys", ctx, userID)
+	ret0, _ := ret[0].([]db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApiKeys indicates an expected call of ListApiKeys.
+func (mr *MockStoreMockRecorder) ListApiKeys(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeys", reflect.TypeOf((*MockStore)(nil).ListApiKeys), ctx, userID)
+}
+
+// ListAuditLogsByTarget mocks base method.
+func (m *MockStore) ListAuditLogsByTarget(ctx context.Context, arg db.ListAuditLogsByTargetParams) ([]db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogsByTarget", ctx, arg)
+	ret0, _ := ret[0].([]db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogsByTarget indicates an expected call of ListAuditLogsByTarget.
+func (mr *MockStoreMockRecorder) ListAuditLogsByTarget(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogsByTarget", reflect.TypeOf((*MockStore)(nil).ListAuditLogsByTarget), ctx, arg)
+}
+
+// ListDueScheduledTransfersForUpdate mocks base method.
+func (m *MockStore) ListDueScheduledTransfersForUpdate(ctx context.Context, arg db.ListDueScheduledTransfersForUpdateParams) ([]db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueScheduledTransfersForUpdate", ctx, arg)
+	ret0, _ := ret[0].([]db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueScheduledTransfersForUpdate indicates an expected call of ListDueScheduledTransfersForUpdate.
+func (mr *MockStoreMockRecorder) ListDueScheduledTransfersForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueScheduledTransfersForUpdate", reflect.TypeOf((*MockStore)(nil).ListDueScheduledTransfersForUpdate), ctx, arg)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+}
+
+// ListPendingScheduledTransferAttempts mocks base method.
+func (m *MockStore) ListPendingScheduledTransferAttempts(ctx context.Context, limit int32) ([]db.ListPendingScheduledTransferAttemptsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingScheduledTransferAttempts", ctx, limit)
+	ret0, _ := ret[0].([]db.ListPendingScheduledTransferAttemptsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingScheduledTransferAttempts indicates an expected call of ListPendingScheduledTransferAttempts.
+func (mr *MockStoreMockRecorder) ListPendingScheduledTransferAttempts(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingScheduledTransferAttempts", reflect.TypeOf((*MockStore)(nil).ListPendingScheduledTransferAttempts), ctx, limit)
+}
+
+// ListRolePermissions mocks base method.
+func (m *MockStore) ListRolePermissions(ctx context.Context) ([]db.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRolePermissions", ctx)
+	ret0, _ := ret[0].([]db.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRolePermissions indicates an expected call of ListRolePermissions.
+func (mr *MockStoreMockRecorder) ListRolePermissions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRolePermissions", reflect.TypeOf((*MockStore)(nil).ListRolePermissions), ctx)
+}
+
+// ListScheduledTransferAttempts mocks base method.
+func (m *MockStore) ListScheduledTransferAttempts(ctx context.Context, arg db.ListScheduledTransferAttemptsParams) ([]db.ScheduledTransferAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledTransferAttempts", ctx, arg)
+	ret0, _ := ret[0].([]db.ScheduledTransferAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledTransferAttempts indicates an expected call of ListScheduledTransferAttempts.
+func (mr *MockStoreMockRecorder) ListScheduledTransferAttempts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransferAttempts", reflect.TypeOf((*MockStore)(nil).ListScheduledTransferAttempts), ctx, arg)
+}
+
+// ListScheduledTransfers mocks base method.
+func (m *MockStore) ListScheduledTransfers(ctx context.Context, arg db.ListScheduledTransfersParams) ([]db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledTransfers indicates an expected call of ListScheduledTransfers.
+func (mr *MockStoreMockRecorder) ListScheduledTransfers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransfers", reflect.TypeOf((*MockStore)(nil).ListScheduledTransfers), ctx, arg)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+// ListStatementEntries mocks base method.
+func (m *MockStore) ListStatementEntries(ctx context.Context, arg db.ListStatementEntriesParams) ([]db.ListStatementEntriesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "ListStatementEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.ListStatementEntriesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+// ListStatementEntries indicates an expected call of ListStatementEntries.
+func (mr *MockStoreMockRecorder) ListStatementEntries(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatementEntries", reflect.TypeOf((*MockStore)(nil).ListStatementEntries), ctx, arg)
 }
 
-// CreateTransfer mocks base method.
-func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTransfer indicates an expected call of CreateTransfer.
-func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg any) *gomock.Call {
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+// LockLogin mocks base method.
+func (m *MockStore) LockLogin(ctx context.Context, arg db.LockLoginParams) (db.LoginAttempt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "LockLogin", ctx, arg)
+	ret0, _ := ret[0].(db.LoginAttempt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockStoreMockRecorder) LockLogin(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockStore)(nil).LockLogin), ctx, arg)
 }
 
-// CreateUserTx mocks base method.
-func (m *MockStore) CreateUserTx(ctx context.Context, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+// LoginExternalIdentityTx mocks base method.
+func (m *MockStore) LoginExternalIdentityTx(ctx context.Context, arg db.LoginExternalIdentityTxParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserTx", ctx, arg)
-	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret := m.ctrl.Call(m, "LoginExternalIdentityTx", ctx, arg)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUserTx indicates an expected call of CreateUserTx.
-func (mr *MockStoreMockRecorder) CreateUserTx(ctx, arg any) *gomock.Call {
+// LoginExternalIdentityTx indicates an expected call of LoginExternalIdentityTx.
+func (mr *MockStoreMockRecorder) LoginExternalIdentityTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginExternalIdentityTx", reflect.TypeOf((*MockStore)(nil).LoginExternalIdentityTx), ctx, arg)
 }
 
-// CreateVerifyEmail mocks base method.
-func (m *MockStore) CreateVerifyEmail(ctx context.Context, arg db.CreateVerifyEmailParams) (db.VerifyEmail, error) {
+// MarkMfaChallengeUsed mocks base method.
+func (m *MockStore) MarkMfaChallengeUsed(ctx context.Context, id int64) (db.MfaChallenge, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateVerifyEmail", ctx, arg)
-	ret0, _ := ret[0].(db.VerifyEmail)
+	ret := m.ctrl.Call(m, "MarkMfaChallengeUsed", ctx, id)
+	ret0, _ := ret[0].(db.MfaChallenge)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateVerifyEmail indicates an expected call of CreateVerifyEmail.
-func (mr *MockStoreMockRecorder) CreateVerifyEmail(ctx, arg any) *gomock.Call {
+// MarkMfaChallengeUsed indicates an expected call of MarkMfaChallengeUsed.
+func (mr *MockStoreMockRecorder) MarkMfaChallengeUsed(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmail", reflect.TypeOf((*MockStore)(nil).CreateVerifyEmail), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMfaChallengeUsed", reflect.TypeOf((*MockStore)(nil).MarkMfaChallengeUsed), ctx, id)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+// MarkSessionUsed mocks base method.
+func (m *MockStore) MarkSessionUsed(ctx context.Context, id uuid.UUID) (db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "MarkSessionUsed", ctx, id)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+// MarkSessionUsed indicates an expected call of MarkSessionUsed.
+func (mr *MockStoreMockRecorder) MarkSessionUsed(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSessionUsed", reflect.TypeOf((*MockStore)(nil).MarkSessionUsed), ctx, id)
 }
 
-// DeleteEntry mocks base method.
-func (m *MockStore) DeleteEntry(ctx context.Context, id int64) error {
+// MarkVerifyEmailUsed mocks base method.
+func (m *MockStore) MarkVerifyEmailUsed(ctx context.Context, id int64) (db.VerifyEmail, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEntry", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "MarkVerifyEmailUsed", ctx, id)
+	ret0, _ := ret[0].(db.VerifyEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteEntry indicates an expected call of DeleteEntry.
-func (mr *MockStoreMockRecorder) DeleteEntry(ctx, id any) *gomock.Call {
+// MarkVerifyEmailUsed indicates an expected call of MarkVerifyEmailUsed.
+func (mr *MockStoreMockRecorder) MarkVerifyEmailUsed(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkVerifyEmailUsed", reflect.TypeOf((*MockStore)(nil).MarkVerifyEmailUsed), ctx, id)
 }
 
-// DeleteTransfer mocks base method.
-func (m *MockStore) DeleteTransfer(ctx context.Context, id int64) error {
+// RecordFailedLogin mocks base method.
+func (m *MockStore) RecordFailedLogin(ctx context.Context, arg db.RecordFailedLoginParams) (db.LoginAttempt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTransfer", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RecordFailedLogin", ctx, arg)
+	ret0, _ := ret[0].(db.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteTransfer indicates an expected call of DeleteTransfer.
-func (mr *MockStoreMockRecorder) DeleteTransfer(ctx, id any) *gomock.Call {
+// RecordFailedLogin indicates an expected call of RecordFailedLogin.
+func (mr *MockStoreMockRecorder) RecordFailedLogin(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockStore)(nil).DeleteTransfer), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLogin", reflect.TypeOf((*MockStore)(nil).RecordFailedLogin), ctx, arg)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+// RecordScheduledTransferFailure mocks base method.
+func (m *MockStore) RecordScheduledTransferFailure(ctx context.Context, arg db.RecordScheduledTransferFailureParams) (db.ScheduledTransfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "RecordScheduledTransferFailure", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+// RecordScheduledTransferFailure indicates an expected call of RecordScheduledTransferFailure.
+func (mr *MockStoreMockRecorder) RecordScheduledTransferFailure(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordScheduledTransferFailure", reflect.TypeOf((*MockStore)(nil).RecordScheduledTransferFailure), ctx, arg)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+// ResetIdempotencyKey mocks base method.
+func (m *MockStore) ResetIdempotencyKey(ctx context.Context, arg db.ResetIdempotencyKeyParams) (db.IdempotencyKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "ResetIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+// ResetIdempotencyKey indicates an expected call of ResetIdempotencyKey.
+func (mr *MockStoreMockRecorder) ResetIdempotencyKey(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetIdempotencyKey", reflect.TypeOf((*MockStore)(nil).ResetIdempotencyKey), ctx, arg)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+// ResetPasswordTx mocks base method.
+func (m *MockStore) ResetPasswordTx(ctx context.Context, arg db.ResetPasswordTxParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "ResetPasswordTx", ctx, arg)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+// ResetPasswordTx indicates an expected call of ResetPasswordTx.
+func (mr *MockStoreMockRecorder) ResetPasswordTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTx", reflect.TypeOf((*MockStore)(nil).ResetPasswordTx), ctx, arg)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
+// ResetScheduledTransferFailures mocks base method.
+func (m *MockStore) ResetScheduledTransferFailures(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", ctx, id)
-	ret0, _ := ret[0].(db.Session)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ResetScheduledTransferFailures", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+// ResetScheduledTransferFailures indicates an expected call of ResetScheduledTransferFailures.
+func (mr *MockStoreMockRecorder) ResetScheduledTransferFailures(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetScheduledTransferFailures", reflect.TypeOf((*MockStore)(nil).ResetScheduledTransferFailures), ctx, id)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+// RetryScheduledTransferAttempt mocks base method.
+func (m *MockStore) RetryScheduledTransferAttempt(ctx context.Context, arg db.RetryScheduledTransferAttemptParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
-	ret0, _ := ret[0].(db.Transfer)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RetryScheduledTransferAttempt", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+// RetryScheduledTransferAttempt indicates an expected call of RetryScheduledTransferAttempt.
+func (mr *MockStoreMockRecorder) RetryScheduledTransferAttempt(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryScheduledTransferAttempt", reflect.TypeOf((*MockStore)(nil).RetryScheduledTransferAttempt), ctx, arg)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(ctx context.Context, userID int64) (db.User, error) {
+// RevokeApiKey mocks base method.
+func (m *MockStore) RevokeApiKey(ctx context.Context, id int64) (db.ApiKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "RevokeApiKey", ctx, id)
+	ret0, _ := ret[0].(db.ApiKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(ctx, userID any) *gomock.Call {
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockStoreMockRecorder) RevokeApiKey(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockStore)(nil).RevokeApiKey), ctx, id)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+// RotateSessionTx mocks base method.
+func (m *MockStore) RotateSessionTx(ctx context.Context, arg db.RotateSessionTxParams) (db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "RotateSessionTx", ctx, arg)
+	ret0, _ := ret[0].(db.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+// RotateSessionTx indicates an expected call of RotateSessionTx.
+func (mr *MockStoreMockRecorder) RotateSessionTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTx", reflect.TypeOf((*MockStore)(nil).RotateSessionTx), ctx, arg)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+// SearchUsers mocks base method.
+func (m *MockStore) SearchUsers(ctx context.Context, arg db.SearchUsersParams) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, arg)
+	ret0, _ := ret[0].([]db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockStoreMockRecorder) SearchUsers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockStore)(nil).SearchUsers), ctx, arg)
 }
 
-// ListEntries mocks base method.
-func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+// SetUserLocked mocks base method.
+func (m *MockStore) SetUserLocked(ctx context.Context, arg db.SetUserLockedParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
-	ret0, _ := ret[0].([]db.Entry)
+	ret := m.ctrl.Call(m, "SetUserLocked", ctx, arg)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEntries indicates an expected call of ListEntries.
-func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+// SetUserLocked indicates an expected call of SetUserLocked.
+func (mr *MockStoreMockRecorder) SetUserLocked(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLocked", reflect.TypeOf((*MockStore)(nil).SetUserLocked), ctx, arg)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.Transfer, error) {
+// StartMfaChallengeTx mocks base method.
+func (m *MockStore) StartMfaChallengeTx(ctx context.Context, arg db.CreateMfaChallengeParams) (db.MfaChallenge, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
-	ret0, _ := ret[0].([]db.Transfer)
+	ret := m.ctrl.Call(m, "StartMfaChallengeTx", ctx, arg)
+	ret0, _ := ret[0].(db.MfaChallenge)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg any) *gomock.Call {
+// StartMfaChallengeTx indicates an expected call of StartMfaChallengeTx.
+func (mr *MockStoreMockRecorder) StartMfaChallengeTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMfaChallengeTx", reflect.TypeOf((*MockStore)(nil).StartMfaChallengeTx), ctx, arg)
 }
 
 // TransferTx mocks base method.
@@ -384,6 +1469,36 @@ func (mr *MockStoreMockRecorder) UpdateAccountBalance(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountBalance", reflect.TypeOf((*MockStore)(nil).UpdateAccountBalance), ctx, arg)
 }
 
+// UpdateAccountOverdraftLimit mocks base method.
+func (m *MockStore) UpdateAccountOverdraftLimit(ctx context.Context, arg db.UpdateAccountOverdraftLimitParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountOverdraftLimit", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountOverdraftLimit indicates an expected call of UpdateAccountOverdraftLimit.
+func (mr *MockStoreMockRecorder) UpdateAccountOverdraftLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountOverdraftLimit", reflect.TypeOf((*MockStore)(nil).UpdateAccountOverdraftLimit), ctx, arg)
+}
+
+// UpdateAccountStatus mocks base method.
+func (m *MockStore) UpdateAccountStatus(ctx context.Context, arg db.UpdateAccountStatusParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountStatus", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountStatus indicates an expected call of UpdateAccountStatus.
+func (mr *MockStoreMockRecorder) UpdateAccountStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockStore)(nil).UpdateAccountStatus), ctx, arg)
+}
+
 // UpdateEntry mocks base method.
 func (m *MockStore) UpdateEntry(ctx context.Context, arg db.UpdateEntryParams) (db.Entry, error) {
 	m.ctrl.T.Helper()
@@ -399,6 +1514,66 @@ func (mr *MockStoreMockRecorder) UpdateEntry(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntry", reflect.TypeOf((*MockStore)(nil).UpdateEntry), ctx, arg)
 }
 
+// UpdateIdempotencyKeyResponse mocks base method.
+func (m *MockStore) UpdateIdempotencyKeyResponse(ctx context.Context, arg db.UpdateIdempotencyKeyResponseParams) (db.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIdempotencyKeyResponse", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIdempotencyKeyResponse indicates an expected call of UpdateIdempotencyKeyResponse.
+func (mr *MockStoreMockRecorder) UpdateIdempotencyKeyResponse(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIdempotencyKeyResponse", reflect.TypeOf((*MockStore)(nil).UpdateIdempotencyKeyResponse), ctx, arg)
+}
+
+// UpdateScheduledTransfer mocks base method.
+func (m *MockStore) UpdateScheduledTransfer(ctx context.Context, arg db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledTransfer indicates an expected call of UpdateScheduledTransfer.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransfer", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransfer), ctx, arg)
+}
+
+// UpdateScheduledTransferTx mocks base method.
+func (m *MockStore) UpdateScheduledTransferTx(ctx context.Context, arg db.UpdateScheduledTransferTxParams) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledTransferTx indicates an expected call of UpdateScheduledTransferTx.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransferTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransferTx", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransferTx), ctx, arg)
+}
+
+// UpdateTotpLastUsedStep mocks base method.
+func (m *MockStore) UpdateTotpLastUsedStep(ctx context.Context, arg db.UpdateTotpLastUsedStepParams) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTotpLastUsedStep", ctx, arg)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTotpLastUsedStep indicates an expected call of UpdateTotpLastUsedStep.
+func (mr *MockStoreMockRecorder) UpdateTotpLastUsedStep(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTotpLastUsedStep", reflect.TypeOf((*MockStore)(nil).UpdateTotpLastUsedStep), ctx, arg)
+}
+
 // UpdateTransfer mocks base method.
 func (m *MockStore) UpdateTransfer(ctx context.Context, arg db.UpdateTransferParams) (db.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -428,3 +1603,93 @@ func (mr *MockStoreMockRecorder) UpdateUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), ctx, arg)
 }
+
+// UpdateUserPendingEmail mocks base method.
+func (m *MockStore) UpdateUserPendingEmail(ctx context.Context, arg db.UpdateUserPendingEmailParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPendingEmail", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPendingEmail indicates an expected call of UpdateUserPendingEmail.
+func (mr *MockStoreMockRecorder) UpdateUserPendingEmail(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPendingEmail", reflect.TypeOf((*MockStore)(nil).UpdateUserPendingEmail), ctx, arg)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockStore) UpdateUserRole(ctx context.Context, arg db.UpdateUserRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockStoreMockRecorder) UpdateUserRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), ctx, arg)
+}
+
+// UpsertTotpCredential mocks base method.
+func (m *MockStore) UpsertTotpCredential(ctx context.Context, arg db.UpsertTotpCredentialParams) (db.TotpCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTotpCredential", ctx, arg)
+	ret0, _ := ret[0].(db.TotpCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTotpCredential indicates an expected call of UpsertTotpCredential.
+func (mr *MockStoreMockRecorder) UpsertTotpCredential(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTotpCredential", reflect.TypeOf((*MockStore)(nil).UpsertTotpCredential), ctx, arg)
+}
+
+// UseMfaRecoveryCode mocks base method.
+func (m *MockStore) UseMfaRecoveryCode(ctx context.Context, arg db.UseMfaRecoveryCodeParams) (db.MfaRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMfaRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(db.MfaRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseMfaRecoveryCode indicates an expected call of UseMfaRecoveryCode.
+func (mr *MockStoreMockRecorder) UseMfaRecoveryCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMfaRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseMfaRecoveryCode), ctx, arg)
+}
+
+// UseOidcState mocks base method.
+func (m *MockStore) UseOidcState(ctx context.Context, stateHash string) (db.OidcState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseOidcState", ctx, stateHash)
+	ret0, _ := ret[0].(db.OidcState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseOidcState indicates an expected call of UseOidcState.
+func (mr *MockStoreMockRecorder) UseOidcState(ctx, stateHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseOidcState", reflect.TypeOf((*MockStore)(nil).UseOidcState), ctx, stateHash)
+}
+
+// VerifyEmailTx mocks base method.
+func (m *MockStore) VerifyEmailTx(ctx context.Context, arg db.VerifyEmailTxParams) (db.VerifyEmailTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmailTx", ctx, arg)
+	ret0, _ := ret[0].(db.VerifyEmailTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmailTx indicates an expected call of VerifyEmailTx.
+func (mr *MockStoreMockRecorder) VerifyEmailTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailTx", reflect.TypeOf((*MockStore)(nil).VerifyEmailTx), ctx, arg)
+}
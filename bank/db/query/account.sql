@@ -14,6 +14,10 @@ SELECT * FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE;
 
+-- name: CountAccounts :one
+SELECT count(*) FROM accounts
+WHERE owner = $1 AND status <> 'closed';
+
 -- name: ListAccounts :many
 SELECT * FROM accounts
 WHERE owner = $1
@@ -34,5 +38,26 @@ WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1;
\ No newline at end of file
+UPDATE accounts
+  set status = 'closed',
+  closed_at = now()
+WHERE id = $1 AND status <> 'closed';
+
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+  set overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: GetAccountBalanceAt :one
+SELECT (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= sqlc.arg(at)
+WHERE a.id = sqlc.arg(account_id)
+GROUP BY a.id;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+  set status = sqlc.arg(status)
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,22 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (
+   user_id, name, key_prefix, key_hash, permissions, expired_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = $1 LIMIT 1;
+
+-- name: ListApiKeys :many
+SELECT * FROM api_keys
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: RevokeApiKey :one
+UPDATE api_keys
+  set revoked_at = now()
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING *;
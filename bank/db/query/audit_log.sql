@@ -0,0 +1,12 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_logs (
+   actor_id, action, target_type, target_id, details
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListAuditLogsByTarget :many
+SELECT * FROM audit_logs
+WHERE target_type = $1 AND target_id = $2
+ORDER BY id DESC;
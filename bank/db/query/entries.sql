@@ -1,8 +1,8 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
-  amount,account_id
+  amount,account_id,transfer_id,balance_after
 ) VALUES (
-  $1,$2
+  $1,$2,$3,$4
 )
 RETURNING *;
 
@@ -10,6 +10,24 @@ RETURNING *;
 SELECT * FROM entries
 WHERE id = $1 LIMIT 1;
 
+-- name: ListAccountEntries :many
+SELECT id, account_id, amount, created_at, balance_after FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time))
+  AND (sqlc.narg(cursor)::bigint IS NULL OR id < sqlc.narg(cursor))
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: ListStatementEntries :many
+SELECT e.id, e.amount, e.created_at, e.transfer_id, t.from_account_id, t.to_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+ORDER BY e.id;
+
 -- name: ListEntries :many
 SELECT * FROM entries
 ORDER BY id
@@ -0,0 +1,11 @@
+-- name: CreateExternalIdentity :one
+INSERT INTO external_identities (
+   user_id, issuer, subject, email
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetExternalIdentity :one
+SELECT * FROM external_identities
+WHERE issuer = $1 AND subject = $2 LIMIT 1;
@@ -0,0 +1,30 @@
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+   user_id, idempotency_key, request_hash, expired_at
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (user_id, idempotency_key) DO NOTHING
+RETURNING *;
+
+-- name: GetIdempotencyKeyForUpdate :one
+SELECT * FROM idempotency_keys
+WHERE user_id = $1 AND idempotency_key = $2 LIMIT 1
+FOR UPDATE;
+
+-- name: ResetIdempotencyKey :one
+UPDATE idempotency_keys
+  set request_hash = $3,
+  transfer_id = NULL,
+  response = '{}',
+  created_at = now(),
+  expired_at = $4
+WHERE user_id = $1 AND idempotency_key = $2
+RETURNING *;
+
+-- name: UpdateIdempotencyKeyResponse :one
+UPDATE idempotency_keys
+  set transfer_id = $3,
+  response = $4
+WHERE user_id = $1 AND idempotency_key = $2
+RETURNING *;
@@ -0,0 +1,27 @@
+-- name: GetLoginAttempt :one
+SELECT * FROM login_attempts
+WHERE scope = $1 AND identifier = $2 LIMIT 1;
+
+-- name: RecordFailedLogin :one
+INSERT INTO login_attempts (
+   scope, identifier, failed_count, last_failed_at
+) VALUES (
+  sqlc.arg(scope), sqlc.arg(identifier), 1, now()
+)
+ON CONFLICT (scope, identifier) DO UPDATE
+  set failed_count = CASE
+    WHEN login_attempts.last_failed_at < sqlc.arg(reset_before) THEN 1
+    ELSE login_attempts.failed_count + 1
+  END,
+  last_failed_at = now()
+RETURNING *;
+
+-- name: LockLogin :one
+UPDATE login_attempts
+  set locked_until = sqlc.arg(locked_until)
+WHERE scope = sqlc.arg(scope) AND identifier = sqlc.arg(identifier)
+RETURNING *;
+
+-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempts
+WHERE scope = $1 AND identifier = $2;
@@ -0,0 +1,34 @@
+-- name: CreateMfaChallenge :one
+INSERT INTO mfa_challenges (
+   user_id, token_hash
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: GetMfaChallengeForUpdate :one
+SELECT * FROM mfa_challenges
+WHERE token_hash = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetUserByMfaChallenge :one
+SELECT users.* FROM users
+JOIN mfa_challenges ON mfa_challenges.user_id = users.user_id
+WHERE mfa_challenges.token_hash = $1 LIMIT 1;
+
+-- name: IncrementMfaChallengeAttempts :one
+UPDATE mfa_challenges
+  set attempts = attempts + 1
+WHERE id = $1
+RETURNING *;
+
+-- name: InvalidateMfaChallenges :exec
+UPDATE mfa_challenges
+  set is_used = true
+WHERE user_id = $1 AND is_used = false;
+
+-- name: MarkMfaChallengeUsed :one
+UPDATE mfa_challenges
+  set is_used = true
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,17 @@
+-- name: CreateMfaRecoveryCode :one
+INSERT INTO mfa_recovery_codes (
+   user_id, code_hash
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: DeleteMfaRecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE user_id = $1;
+
+-- name: UseMfaRecoveryCode :one
+UPDATE mfa_recovery_codes
+  set used_at = now()
+WHERE user_id = sqlc.arg(user_id) AND code_hash = sqlc.arg(code_hash) AND used_at IS NULL
+RETURNING *;
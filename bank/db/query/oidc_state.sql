@@ -0,0 +1,13 @@
+-- name: CreateOidcState :one
+INSERT INTO oidc_states (
+   state_hash, code_verifier, nonce
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: UseOidcState :one
+UPDATE oidc_states
+  set is_used = true
+WHERE state_hash = $1 AND is_used = false AND expired_at > now()
+RETURNING *;
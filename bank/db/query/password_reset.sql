@@ -0,0 +1,17 @@
+-- name: CreatePasswordReset :one
+INSERT INTO password_resets (
+   user_id, token_hash
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: GetPasswordResetForUpdate :one
+SELECT * FROM password_resets
+WHERE token_hash = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: InvalidatePasswordResets :exec
+UPDATE password_resets
+  set is_used = true
+WHERE user_id = $1 AND is_used = false;
@@ -0,0 +1,71 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner, from_account_id, to_account_id, amount, cron_expression, interval_seconds, next_run_at, end_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+  set amount = $2,
+  cron_expression = $3,
+  interval_seconds = $4,
+  next_run_at = $5,
+  end_at = $6,
+  status = $7,
+  consecutive_failures = $8,
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteScheduledTransfer :exec
+DELETE FROM scheduled_transfers
+WHERE id = $1;
+
+-- name: DisableAccountScheduledTransfers :exec
+UPDATE scheduled_transfers
+  set status = 'disabled',
+  updated_at = now()
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+  AND status IN ('active', 'paused');
+
+-- name: ListDueScheduledTransfersForUpdate :many
+SELECT * FROM scheduled_transfers
+WHERE status = 'active' AND next_run_at <= sqlc.arg(now)
+ORDER BY next_run_at
+LIMIT sqlc.arg(limit_count)
+FOR NO KEY UPDATE SKIP LOCKED;
+
+-- name: RecordScheduledTransferFailure :one
+UPDATE scheduled_transfers
+  set consecutive_failures = consecutive_failures + 1,
+  status = CASE
+    WHEN consecutive_failures + 1 >= sqlc.arg(max_failures)::int THEN 'disabled'::scheduled_transfer_status
+    ELSE status
+  END,
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ResetScheduledTransferFailures :exec
+UPDATE scheduled_transfers
+  set consecutive_failures = 0,
+  updated_at = now()
+WHERE id = $1 AND consecutive_failures > 0;
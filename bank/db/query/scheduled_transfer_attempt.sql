@@ -0,0 +1,40 @@
+-- name: CreateScheduledTransferAttempt :one
+INSERT INTO scheduled_transfer_attempts (
+  scheduled_transfer_id, scheduled_for
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (scheduled_transfer_id, scheduled_for) DO NOTHING
+RETURNING *;
+
+-- name: ListPendingScheduledTransferAttempts :many
+SELECT a.id, a.scheduled_transfer_id, a.scheduled_for, a.retries,
+  s.owner, s.from_account_id, s.to_account_id, s.amount, s.status AS schedule_status
+FROM scheduled_transfer_attempts a
+JOIN scheduled_transfers s ON s.id = a.scheduled_transfer_id
+WHERE a.status = 'pending'
+ORDER BY a.id
+LIMIT $1;
+
+-- name: CompleteScheduledTransferAttempt :one
+UPDATE scheduled_transfer_attempts
+  set status = $2,
+  transfer_id = $3,
+  failure_reason = $4,
+  updated_at = now()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: RetryScheduledTransferAttempt :exec
+UPDATE scheduled_transfer_attempts
+  set retries = retries + 1,
+  failure_reason = $2,
+  updated_at = now()
+WHERE id = $1 AND status = 'pending';
+
+-- name: ListScheduledTransferAttempts :many
+SELECT * FROM scheduled_transfer_attempts
+WHERE scheduled_transfer_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
@@ -1,11 +1,41 @@
 -- name: CreateSession :one
 INSERT INTO sessions (
-   id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at
+   id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at, family_id, parent_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
 RETURNING *;
 
 -- name: GetSession :one
+SELECT sqlc.embed(sessions), users.password_changed_at FROM sessions
+JOIN users ON users.user_id = sessions.user_id
+WHERE sessions.id = $1 LIMIT 1;
+
+-- name: GetSessionForUpdate :one
 SELECT * FROM sessions
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: MarkSessionUsed :one
+UPDATE sessions
+  set is_used = true
+WHERE id = $1
+RETURNING *;
+
+-- name: BlockSessionFamily :exec
+UPDATE sessions
+  set is_blocked = true
+WHERE family_id = $1;
+
+-- name: ListActiveSessions :many
+SELECT * FROM sessions
+WHERE user_id = $1
+  AND is_blocked = false
+  AND is_used = false
+  AND expired_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockUserSessions :exec
+UPDATE sessions
+  set is_blocked = true
+WHERE user_id = $1 AND is_blocked = false;
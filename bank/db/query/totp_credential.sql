@@ -0,0 +1,35 @@
+-- name: UpsertTotpCredential :one
+INSERT INTO totp_credentials (
+   user_id, secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+  set secret = EXCLUDED.secret,
+  last_used_step = 0,
+  created_at = now()
+WHERE totp_credentials.is_enabled = false
+RETURNING *;
+
+-- name: GetTotpCredential :one
+SELECT * FROM totp_credentials
+WHERE user_id = $1 LIMIT 1;
+
+-- name: GetTotpCredentialForUpdate :one
+SELECT * FROM totp_credentials
+WHERE user_id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: EnableTotpCredential :one
+UPDATE totp_credentials
+  set is_enabled = true,
+  enabled_at = now(),
+  last_used_step = sqlc.arg(last_used_step)
+WHERE user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: UpdateTotpLastUsedStep :one
+UPDATE totp_credentials
+  set last_used_step = sqlc.arg(last_used_step)
+WHERE user_id = sqlc.arg(user_id)
+RETURNING *;
@@ -19,6 +19,40 @@ UPDATE users
   set email = coalesce(sqlc.narg('email'), email),
   full_name = coalesce(sqlc.narg('full_name'), full_name),
   hashed_password = coalesce(sqlc.narg('hashed_password'), hashed_password),
-  password_changed_at = coalesce(sqlc.narg('password_changed_at'), password_changed_at)
+  password_changed_at = coalesce(sqlc.narg('password_changed_at'), password_changed_at),
+  is_email_verified = coalesce(sqlc.narg('is_email_verified'), is_email_verified)
 WHERE user_id = sqlc.arg('user_id')
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+-- name: UpdateUserPendingEmail :one
+UPDATE users
+  set pending_email = sqlc.narg('pending_email')
+WHERE user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- name: ApplyPendingEmail :one
+UPDATE users
+  set email = pending_email,
+  pending_email = NULL,
+  is_email_verified = true
+WHERE user_id = $1 AND pending_email IS NOT NULL
+RETURNING *;
+
+-- name: SearchUsers :many
+SELECT * FROM users
+WHERE email ILIKE '%' || replace(replace(replace(sqlc.arg(query)::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\'
+  OR full_name ILIKE '%' || replace(replace(replace(sqlc.arg(query)::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\'
+ORDER BY user_id
+LIMIT sqlc.arg(limit_count)
+OFFSET sqlc.arg(offset_count);
+
+-- name: UpdateUserRole :one
+UPDATE users
+  set role = $1
+WHERE user_id = $2
+RETURNING *;
+
+-- name: SetUserLocked :one
+UPDATE users
+  set is_locked = $1
+WHERE user_id = $2
+RETURNING *;
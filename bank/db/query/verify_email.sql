@@ -4,4 +4,21 @@ INSERT INTO verify_emails (
 ) VALUES (
   $1, $2, $3
 )
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetVerifyEmailForUpdate :one
+SELECT * FROM verify_emails
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetLatestVerifyEmail :one
+SELECT * FROM verify_emails
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: MarkVerifyEmailUsed :one
+UPDATE verify_emails
+  set is_used = true
+WHERE id = $1
+RETURNING *;
@@ -7,15 +7,28 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
+const countAccounts = `-- name: CountAccounts :one
+SELECT count(*) FROM accounts
+WHERE owner = $1 AND status <> 'closed'
+`
+
+func (q *Queries) CountAccounts(ctx context.Context, owner int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAccounts, owner)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
   owner, balance,currency
 ) VALUES (
   $1, $2, $3
 )
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, closed_at
 `
 
 type CreateAccountParams struct {
@@ -33,13 +46,18 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
 const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1
+UPDATE accounts
+  set status = 'closed',
+  closed_at = now()
+WHERE id = $1 AND status <> 'closed'
 `
 
 func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
@@ -48,7 +66,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit, status, closed_at FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -61,12 +79,35 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
+const getAccountBalanceAt = `-- name: GetAccountBalanceAt :one
+SELECT (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= $1
+WHERE a.id = $2
+GROUP BY a.id
+`
+
+type GetAccountBalanceAtParams struct {
+	At        time.Time `json:"at"`
+	AccountID int64     `json:"account_id"`
+}
+
+func (q *Queries) GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getAccountBalanceAt, arg.At, arg.AccountID)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit, status, closed_at FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -80,12 +121,15 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit, status, closed_at FROM accounts
 WHERE owner = $1
 ORDER BY id
 LIMIT $2
@@ -113,6 +157,9 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.OverdraftLimit,
+			&i.Status,
+			&i.ClosedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -131,7 +178,7 @@ const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
   set balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, closed_at
 `
 
 type UpdateAccountParams struct {
@@ -148,6 +195,9 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.ClosedAt,
 	)
 	return i, err
 }
@@ -156,7 +206,7 @@ const updateAccountBalance = `-- name: UpdateAccountBalance :one
 UPDATE accounts
   set balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, closed_at
 `
 
 type UpdateAccountBalanceParams struct {
@@ -173,6 +223,65 @@ func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBal
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+  set overdraft_limit = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, closed_at
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	ID             int64 `json:"id"`
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountOverdraftLimit, arg.OverdraftLimit, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+  set status = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, closed_at
+`
+
+type UpdateAccountStatusParams struct {
+	Status AccountStatus `json:"status"`
+	ID     int64         `json:"id"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.Status, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.ClosedAt,
 	)
 	return i, err
 }
@@ -2,7 +2,6 @@ package db
 
 import (
 	"context"
-	"database/sql"
 	"main/util"
 	"testing"
 	"time"
@@ -14,7 +13,7 @@ func createTestAccount(t *testing.T) Account {
 	user := createTestUser(t)
 	arg := CreateAccountParams{
 		Owner:    user.UserID,
-		Balance:  util.RandomMoney(),
+		Balance:  util.RandomInt(1000, 200000),
 		Currency: util.RandomCurrency(),
 	}
 	account, err := testQueries.CreateAccount(context.Background(), arg)
@@ -68,10 +67,12 @@ func TestDeleteAccount(t *testing.T) {
 	err := testQueries.DeleteAccount(context.Background(), account.ID)
 	require.NoError(t, err)
 
+	// the row stays so its entries and transfers keep their account
 	account2, err := testQueries.GetAccount(context.Background(), account.ID)
-	require.Error(t, err)
-	require.EqualError(t, err, sql.ErrNoRows.Error())
-	require.Empty(t, account2)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, account2.Status)
+	require.True(t, account2.ClosedAt.Valid)
+	require.WithinDuration(t, time.Now(), account2.ClosedAt.Time, time.Second)
 }
 
 func TestGetListAccounts(t *testing.T) {
@@ -93,3 +94,21 @@ func TestGetListAccounts(t *testing.T) {
 		require.Equal(t, account.Owner, lastAccount.Owner)
 	}
 }
+
+func TestCountAccountsSkipsClosed(t *testing.T) {
+	account := createTestAccount(t)
+
+	count, err := testQueries.CountAccounts(context.Background(), account.Owner)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status: AccountStatusClosed,
+		ID:     account.ID,
+	})
+	require.NoError(t, err)
+
+	count, err = testQueries.CountAccounts(context.Background(), account.Owner)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAccountFrozen   = errors.New("account is frozen")
+	ErrAccountClosed   = errors.New("account is closed")
+	ErrAccountNotEmpty = errors.New("account balance is not zero")
+	ErrInvalidSweep    = errors.New("sweep must move the balance out of the closed account")
+)
+
+// checkAccountStatus refuses money movements in or out of an account that
+// isn't active.
+func checkAccountStatus(account Account) error {
+	switch account.Status {
+	case AccountStatusFrozen:
+		return fmt.Errorf("account %d: %w", account.ID, ErrAccountFrozen)
+	case AccountStatusClosed:
+		return fmt.Errorf("account %d: %w", account.ID, ErrAccountClosed)
+	}
+	return nil
+}
+
+// CloseAccountTxParams closes AccountID. An account still holding money is
+// only closed together with a Sweep transferring its whole balance to
+// another account, the balance is compared under the row lock so nothing
+// can arrive in between.
+type CloseAccountTxParams struct {
+	AccountID int64
+	Sweep     *TransferTxParams
+}
+
+type CloseAccountTxResult struct {
+	Account Account           `json:"account"`
+	Sweep   *TransferTxResult `json:"sweep"`
+}
+
+// CloseAccountTx marks the account closed instead of deleting it, its
+// entries and transfers stay readable. Scheduled transfers from or to the
+// account are disabled with it.
+func (store *StoreSQL) CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error) {
+	var result CloseAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := checkAccountStatus(account); err != nil {
+			return err
+		}
+
+		var sweepAmount int64
+		if arg.Sweep != nil {
+			if arg.Sweep.FromAccountId != account.ID {
+				return ErrInvalidSweep
+			}
+			sweepAmount = arg.Sweep.Amount
+		}
+		if account.Balance != sweepAmount {
+			return fmt.Errorf("account %d holds %d %s: %w", account.ID, account.Balance, account.Currency, ErrAccountNotEmpty)
+		}
+		if arg.Sweep != nil {
+			sweep, err := transfer(ctx, q, *arg.Sweep)
+			if err != nil {
+				return err
+			}
+			result.Sweep = &sweep
+		}
+
+		if err := q.DisableAccountScheduledTransfers(ctx, account.ID); err != nil {
+			return err
+		}
+		if err := q.DeleteAccount(ctx, account.ID); err != nil {
+			return err
+		}
+		result.Account, err = q.GetAccount(ctx, account.ID)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxTransferInactiveAccount(t *testing.T) {
+	store := NewStore(testDb)
+
+	for _, accountStatus := range []AccountStatus{AccountStatusFrozen, AccountStatusClosed} {
+		ac1 := createTestAccount(t)
+		ac2 := createTestAccount(t)
+		_, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{Status: accountStatus, ID: ac2.ID})
+		require.NoError(t, err)
+
+		_, err = store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountId: ac1.ID,
+			ToAccountId:   ac2.ID,
+			Amount:        10,
+		})
+		if accountStatus == AccountStatusFrozen {
+			require.ErrorIs(t, err, ErrAccountFrozen)
+		} else {
+			require.ErrorIs(t, err, ErrAccountClosed)
+		}
+
+		// the balance updates are rolled back with the transfer
+		got, err := testQueries.GetAccount(context.Background(), ac1.ID)
+		require.NoError(t, err)
+		require.Equal(t, ac1.Balance, got.Balance)
+	}
+}
+
+func TestCloseAccountTx(t *testing.T) {
+	store := NewStore(testDb)
+	account := createTestAccount(t)
+
+	// an account holding money isn't closed without a sweep
+	_, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrAccountNotEmpty)
+
+	// owners hold one open account per currency
+	sweepCurrency := util.RandomCurrency()
+	for sweepCurrency == account.Currency {
+		sweepCurrency = util.RandomCurrency()
+	}
+	sweepAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner,
+		Balance:  0,
+		Currency: sweepCurrency,
+	})
+	require.NoError(t, err)
+
+	result, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID: account.ID,
+		Sweep: &TransferTxParams{
+			FromAccountId: account.ID,
+			ToAccountId:   sweepAccount.ID,
+			Amount:        account.Balance,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+	require.True(t, result.Account.ClosedAt.Valid)
+	require.Zero(t, result.Account.Balance)
+	require.NotNil(t, result.Sweep)
+	require.Equal(t, account.Balance, result.Sweep.ToAccount.Balance)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	// the currency is free again for a new account
+	_, err = testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner,
+		Balance:  0,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+}
+
+func TestCloseAccountTxFrozen(t *testing.T) {
+	store := NewStore(testDb)
+	account := createTestAccount(t)
+	_, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{ID: account.ID, Balance: 0})
+	require.NoError(t, err)
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{Status: AccountStatusFrozen, ID: account.ID})
+	require.NoError(t, err)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestApiKey(t *testing.T, user User) ApiKey {
+	arg := CreateApiKeyParams{
+		UserID:      user.UserID,
+		Name:        util.RandomStr(8),
+		KeyPrefix:   "sbk_" + util.RandomStr(8),
+		KeyHash:     util.HashSecretToken(util.RandomStr(32)),
+		Permissions: []string{"accounts:read", "transfers:create"},
+		ExpiredAt:   time.Now().Add(time.Hour),
+	}
+	apiKey, err := testQueries.CreateApiKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.UserID, apiKey.UserID)
+	require.Equal(t, arg.KeyHash, apiKey.KeyHash)
+	require.Equal(t, arg.Permissions, apiKey.Permissions)
+	require.WithinDuration(t, arg.ExpiredAt, apiKey.ExpiredAt, time.Second)
+	require.False(t, apiKey.RevokedAt.Valid)
+	return apiKey
+}
+
+func TestApiKey(t *testing.T) {
+	user := createTestUser(t)
+	apiKey := createTestApiKey(t, user)
+	createTestApiKey(t, user)
+
+	got, err := testQueries.GetApiKeyByHash(context.Background(), apiKey.KeyHash)
+	require.NoError(t, err)
+	require.Equal(t, apiKey.ID, got.ID)
+	require.Equal(t, apiKey.Permissions, got.Permissions)
+
+	apiKeys, err := testQueries.ListApiKeys(context.Background(), user.UserID)
+	require.NoError(t, err)
+	require.Len(t, apiKeys, 2)
+
+	revoked, err := testQueries.RevokeApiKey(context.Background(), apiKey.ID)
+	require.NoError(t, err)
+	require.True(t, revoked.RevokedAt.Valid)
+
+	// revoking twice doesn't move revoked_at
+	_, err = testQueries.RevokeApiKey(context.Background(), apiKey.ID)
+	require.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminActionTx(t *testing.T) {
+	store := NewStore(testDb)
+	admin := createTestUser(t)
+	user := createTestUser(t)
+	targetID := strconv.FormatInt(user.UserID, 10)
+
+	auditLog, err := store.AdminActionTx(context.Background(), AdminActionTxParams{
+		ActorID:    admin.UserID,
+		Action:     "users.lock",
+		TargetType: "user",
+		TargetID:   targetID,
+		Apply: func(q *Queries) (any, error) {
+			_, err := q.SetUserLocked(context.Background(), SetUserLockedParams{IsLocked: true, UserID: user.UserID})
+			return map[string]string{"reason": "fraud"}, err
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, admin.UserID, auditLog.ActorID)
+	require.JSONEq(t, `{"reason":"fraud"}`, string(auditLog.Details))
+
+	locked, err := testQueries.GetUser(context.Background(), user.UserID)
+	require.NoError(t, err)
+	require.True(t, locked.IsLocked)
+
+	auditLogs, err := testQueries.ListAuditLogsByTarget(context.Background(), ListAuditLogsByTargetParams{
+		TargetType: "user",
+		TargetID:   targetID,
+	})
+	require.NoError(t, err)
+	require.Len(t, auditLogs, 1)
+	require.Equal(t, auditLog.ID, auditLogs[0].ID)
+}
+
+func TestAdminActionTxRollback(t *testing.T) {
+	store := NewStore(testDb)
+	admin := createTestUser(t)
+	account := createTestAccount(t)
+	targetID := strconv.FormatInt(account.ID, 10)
+
+	applyErr := errors.New("apply failed")
+	_, err := store.AdminActionTx(context.Background(), AdminActionTxParams{
+		ActorID:    admin.UserID,
+		Action:     "accounts.freeze",
+		TargetType: "account",
+		TargetID:   targetID,
+		Apply: func(q *Queries) (any, error) {
+			_, err := q.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{Status: AccountStatusFrozen, ID: account.ID})
+			require.NoError(t, err)
+			return nil, applyErr
+		},
+	})
+	require.ErrorIs(t, err, applyErr)
+
+	// neither the change nor the log entry is kept
+	got, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, got.Status)
+
+	auditLogs, err := testQueries.ListAuditLogsByTarget(context.Background(), ListAuditLogsByTargetParams{
+		TargetType: "account",
+		TargetID:   targetID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, auditLogs)
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrUserLocked = errors.New("user has been locked by an administrator")
+
+// AdminActionTxParams describes a change made by an admin. Apply makes the
+// change and returns the details stored with it, it runs in the same
+// transaction as the audit log entry so no change goes unrecorded.
+type AdminActionTxParams struct {
+	ActorID    int64
+	Action     string
+	TargetType string
+	TargetID   string
+	Apply      func(q *Queries) (details any, err error)
+}
+
+func (store *StoreSQL) AdminActionTx(ctx context.Context, arg AdminActionTxParams) (AuditLog, error) {
+	var auditLog AuditLog
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		details, err := arg.Apply(q)
+		if err != nil {
+			return err
+		}
+		detailsJSON := json.RawMessage("{}")
+		if details != nil {
+			detailsJSON, err = json.Marshal(details)
+			if err != nil {
+				return fmt.Errorf("failed to marshal audit details: %w", err)
+			}
+		}
+
+		auditLog, err = q.CreateAuditLog(ctx, CreateAuditLogParams{
+			ActorID:    arg.ActorID,
+			Action:     arg.Action,
+			TargetType: arg.TargetType,
+			TargetID:   arg.TargetID,
+			Details:    detailsJSON,
+		})
+		return err
+	})
+
+	return auditLog, err
+}
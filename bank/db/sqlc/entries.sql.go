@@ -7,30 +7,41 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
-  amount,account_id
+  amount,account_id,transfer_id,balance_after
 ) VALUES (
-  $1,$2
+  $1,$2,$3,$4
 )
-RETURNING id, amount, account_id, created_at
+RETURNING id, amount, account_id, created_at, transfer_id, balance_after
 `
 
 type CreateEntryParams struct {
-	Amount    int64 `json:"amount"`
-	AccountID int64 `json:"account_id"`
+	Amount       int64         `json:"amount"`
+	AccountID    int64         `json:"account_id"`
+	TransferID   sql.NullInt64 `json:"transfer_id"`
+	BalanceAfter int64         `json:"balance_after"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.Amount, arg.AccountID)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.Amount,
+		arg.AccountID,
+		arg.TransferID,
+		arg.BalanceAfter,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.Amount,
 		&i.AccountID,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.BalanceAfter,
 	)
 	return i, err
 }
@@ -46,7 +57,7 @@ func (q *Queries) DeleteEntry(ctx context.Context, id int64) error {
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, amount, account_id, created_at FROM entries
+SELECT id, amount, account_id, created_at, transfer_id, balance_after FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -58,12 +69,75 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.Amount,
 		&i.AccountID,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.BalanceAfter,
 	)
 	return i, err
 }
 
+const listAccountEntries = `-- name: ListAccountEntries :many
+SELECT id, account_id, amount, created_at, balance_after FROM entries
+WHERE account_id = $1
+  AND ($2::timestamptz IS NULL OR created_at >= $2)
+  AND ($3::timestamptz IS NULL OR created_at < $3)
+  AND ($4::bigint IS NULL OR id < $4)
+ORDER BY id DESC
+LIMIT $5
+`
+
+type ListAccountEntriesParams struct {
+	AccountID int64         `json:"account_id"`
+	FromTime  sql.NullTime  `json:"from_time"`
+	ToTime    sql.NullTime  `json:"to_time"`
+	Cursor    sql.NullInt64 `json:"cursor"`
+	PageSize  int32         `json:"page_size"`
+}
+
+type ListAccountEntriesRow struct {
+	ID           int64     `json:"id"`
+	AccountID    int64     `json:"account_id"`
+	Amount       int64     `json:"amount"`
+	CreatedAt    time.Time `json:"created_at"`
+	BalanceAfter int64     `json:"balance_after"`
+}
+
+func (q *Queries) ListAccountEntries(ctx context.Context, arg ListAccountEntriesParams) ([]ListAccountEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountEntries,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Cursor,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountEntriesRow{}
+	for rows.Next() {
+		var i ListAccountEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.BalanceAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, amount, account_id, created_at FROM entries
+SELECT id, amount, account_id, created_at, transfer_id, balance_after FROM entries
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -88,6 +162,63 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.Amount,
 			&i.AccountID,
 			&i.CreatedAt,
+			&i.TransferID,
+			&i.BalanceAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT e.id, e.amount, e.created_at, e.transfer_id, t.from_account_id, t.to_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = $1
+  AND e.created_at >= $2
+  AND e.created_at < $3
+ORDER BY e.id
+`
+
+type ListStatementEntriesParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+type ListStatementEntriesRow struct {
+	ID            int64         `json:"id"`
+	Amount        int64         `json:"amount"`
+	CreatedAt     time.Time     `json:"created_at"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	FromAccountID sql.NullInt64 `json:"from_account_id"`
+	ToAccountID   sql.NullInt64 `json:"to_account_id"`
+}
+
+func (q *Queries) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStatementEntries, arg.AccountID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementEntriesRow{}
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.FromAccountID,
+			&i.ToAccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -106,7 +237,7 @@ const updateEntry = `-- name: UpdateEntry :one
 UPDATE entries
   set amount = $2
 WHERE id = $1
-RETURNING id, amount, account_id, created_at
+RETURNING id, amount, account_id, created_at, transfer_id, balance_after
 `
 
 type UpdateEntryParams struct {
@@ -122,6 +253,8 @@ func (q *Queries) UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry
 		&i.Amount,
 		&i.AccountID,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.BalanceAfter,
 	)
 	return i, err
 }
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAccountEntries(t *testing.T) {
+	store := NewStore(testDb)
+
+	ac1 := createTestAccount(t)
+	ac2 := createTestAccount(t)
+
+	n := 5
+	amount := int64(10)
+	for i := 0; i < n; i++ {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountId: ac1.ID,
+			ToAccountId:   ac2.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+	}
+
+	entries, err := testQueries.ListAccountEntries(context.Background(), ListAccountEntriesParams{
+		AccountID: ac1.ID,
+		PageSize:  3,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	// newest first, the newest entry leaves the current balance
+	for i, entry := range entries {
+		require.Equal(t, ac1.ID, entry.AccountID)
+		require.Equal(t, -amount, entry.Amount)
+		require.Equal(t, ac1.Balance-int64(n-i)*amount, entry.BalanceAfter)
+	}
+
+	nextPage, err := testQueries.ListAccountEntries(context.Background(), ListAccountEntriesParams{
+		AccountID: ac1.ID,
+		Cursor:    sql.NullInt64{Int64: entries[len(entries)-1].ID, Valid: true},
+		PageSize:  3,
+	})
+	require.NoError(t, err)
+	require.Len(t, nextPage, n-3)
+	require.Less(t, nextPage[0].ID, entries[len(entries)-1].ID)
+	require.Equal(t, ac1.Balance-amount, nextPage[len(nextPage)-1].BalanceAfter)
+
+	future, err := testQueries.ListAccountEntries(context.Background(), ListAccountEntriesParams{
+		AccountID: ac1.ID,
+		FromTime:  sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		PageSize:  3,
+	})
+	require.NoError(t, err)
+	require.Empty(t, future)
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	ForeignKeyViolation = "23503"
+	UniqueViolation     = "23505"
+	CheckViolation      = "23514"
+)
+
+const accountBalanceConstraint = "accounts_balance_check"
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrorCode returns the postgres error code of err, or an empty string when
+// err doesn't come from postgres.
+func ErrorCode(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	return ""
+}
+
+func isInsufficientFunds(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && string(pqErr.Code) == CheckViolation && pqErr.Constraint == accountBalanceConstraint
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type LoginExternalIdentityTxParams struct {
+	Issuer  string `json:"issuer"`
+	Subject string `json:"subject"`
+	// Email has to be verified by the identity provider, an existing user
+	// with that email gets the identity linked.
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	// HashedPassword is set on a user created for the identity, it's the hash
+	// of a random password nobody knows until it is reset.
+	HashedPassword string `json:"hashed_password"`
+}
+
+// LoginExternalIdentityTx returns the user an identity of an OIDC provider
+// signs in as, linking it by email or creating the user the first time.
+func (store *StoreSQL) LoginExternalIdentityTx(ctx context.Context, arg LoginExternalIdentityTxParams) (User, error) {
+	var result User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		identity, err := q.GetExternalIdentity(ctx, GetExternalIdentityParams{
+			Issuer:  arg.Issuer,
+			Subject: arg.Subject,
+		})
+		if err == nil {
+			result, err = q.GetUser(ctx, identity.UserID)
+			return err
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		user, err := q.GetUserByEmail(ctx, arg.Email)
+		if err == sql.ErrNoRows {
+			user, err = q.CreateUser(ctx, CreateUserParams{
+				HashedPassword: arg.HashedPassword,
+				FullName:       arg.FullName,
+				Email:          arg.Email,
+				Role:           UserRoleUser,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		if !user.IsEmailVerified {
+			// the identity provider verified the email already
+			user, err = q.UpdateUser(ctx, UpdateUserParams{
+				IsEmailVerified: sql.NullBool{Bool: true, Valid: true},
+				UserID:          user.UserID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = q.CreateExternalIdentity(ctx, CreateExternalIdentityParams{
+			UserID:  user.UserID,
+			Issuer:  arg.Issuer,
+			Subject: arg.Subject,
+			Email:   arg.Email,
+		})
+		if err != nil {
+			return err
+		}
+		result = user
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrIdempotencyKeyConflict = errors.New("idempotency key was already used with a different request")
+
+type IdempotentTransferTxParams struct {
+	TransferTxParams
+	UserID         int64         `json:"user_id"`
+	IdempotencyKey string        `json:"idempotency_key"`
+	RequestHash    string        `json:"request_hash"`
+	Window         time.Duration `json:"window"`
+}
+
+// IdempotentTransferTx runs the transfer at most once per user and idempotency
+// key. A replay inside the window returns the stored result of the first call,
+// and a replay with a different request hash fails with ErrIdempotencyKeyConflict.
+func (store *StoreSQL) IdempotentTransferTx(ctx context.Context, arg IdempotentTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		now := time.Now()
+		// A concurrent request holding the same key blocks this insert until it
+		// commits, after which the key is read back below.
+		_, err := q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+			UserID:         arg.UserID,
+			IdempotencyKey: arg.IdempotencyKey,
+			RequestHash:    arg.RequestHash,
+			ExpiredAt:      now.Add(arg.Window),
+		})
+		if err != nil {
+			if err != sql.ErrNoRows {
+				return err
+			}
+
+			key, err := q.GetIdempotencyKeyForUpdate(ctx, GetIdempotencyKeyForUpdateParams{
+				UserID:         arg.UserID,
+				IdempotencyKey: arg.IdempotencyKey,
+			})
+			if err != nil {
+				return err
+			}
+			if key.ExpiredAt.After(now) {
+				if key.RequestHash != arg.RequestHash {
+					return ErrIdempotencyKeyConflict
+				}
+				return json.Unmarshal(key.Response, &result)
+			}
+
+			_, err = q.ResetIdempotencyKey(ctx, ResetIdempotencyKeyParams{
+				UserID:         arg.UserID,
+				IdempotencyKey: arg.IdempotencyKey,
+				RequestHash:    arg.RequestHash,
+				ExpiredAt:      now.Add(arg.Window),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = transfer(ctx, q, arg.TransferTxParams)
+		if err != nil {
+			return err
+		}
+
+		response, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, err = q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+			UserID:         arg.UserID,
+			IdempotencyKey: arg.IdempotencyKey,
+			TransferID:     sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+			Response:       response,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordFailedLogin(t *testing.T) {
+	arg := RecordFailedLoginParams{
+		Scope:       "email",
+		Identifier:  util.RandomEmail(),
+		ResetBefore: time.Now().Add(-time.Minute),
+	}
+	for i := 1; i <= 3; i++ {
+		attempt, err := testQueries.RecordFailedLogin(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, int32(i), attempt.FailedCount)
+	}
+
+	// failures before reset_before are forgotten
+	arg.ResetBefore = time.Now().Add(time.Minute)
+	attempt, err := testQueries.RecordFailedLogin(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), attempt.FailedCount)
+
+	lockedUntil := time.Now().Add(time.Hour)
+	attempt, err = testQueries.LockLogin(context.Background(), LockLoginParams{
+		LockedUntil: lockedUntil,
+		Scope:       arg.Scope,
+		Identifier:  arg.Identifier,
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, lockedUntil, attempt.LockedUntil, time.Second)
+
+	err = testQueries.DeleteLoginAttempt(context.Background(), DeleteLoginAttemptParams{Scope: arg.Scope, Identifier: arg.Identifier})
+	require.NoError(t, err)
+	_, err = testQueries.GetLoginAttempt(context.Background(), GetLoginAttemptParams{Scope: arg.Scope, Identifier: arg.Identifier})
+	require.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"main/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func enableTestTOTP(t *testing.T, user User, recoveryCodeHashes []string) TotpCredential {
+	store := NewStore(testDb)
+	_, err := testQueries.UpsertTotpCredential(context.Background(), UpsertTotpCredentialParams{
+		UserID: user.UserID,
+		Secret: util.RandomStr(32),
+	})
+	require.NoError(t, err)
+
+	credential, err := store.EnableTOTPTx(context.Background(), EnableTOTPTxParams{
+		UserID: user.UserID,
+		VerifyCode: func(credential TotpCredential) (int64, error) {
+			return 100, nil
+		},
+		RecoveryCodeHashes: recoveryCodeHashes,
+	})
+	require.NoError(t, err)
+	require.True(t, credential.IsEnabled)
+	require.Equal(t, int64(100), credential.LastUsedStep)
+	return credential
+}
+
+func createTestMfaChallenge(t *testing.T, user User) string {
+	token, err := util.NewSecretToken(32)
+	require.NoError(t, err)
+	_, err = testQueries.CreateMfaChallenge(context.Background(), CreateMfaChallengeParams{
+		UserID:    user.UserID,
+		TokenHash: util.HashSecretToken(token),
+	})
+	require.NoError(t, err)
+	return util.HashSecretToken(token)
+}
+
+func TestEnableTOTPTx(t *testing.T) {
+	store := NewStore(testDb)
+	user := createTestUser(t)
+	enableTestTOTP(t, user, []string{util.RandomStr(20)})
+
+	_, err := store.EnableTOTPTx(context.Background(), EnableTOTPTxParams{
+		UserID: user.UserID,
+		VerifyCode: func(credential TotpCredential) (int64, error) {
+			return 101, nil
+		},
+	})
+	require.ErrorIs(t, err, ErrTOTPAlreadyEnabled)
+}
+
+func TestCompleteMfaChallengeTx(t *testing.T) {
+	store := NewStore(testDb)
+	user := createTestUser(t)
+	enableTestTOTP(t, user, nil)
+	tokenHash := createTestMfaChallenge(t, user)
+
+	// wrong codes are counted on the challenge
+	_, err := store.CompleteMfaChallengeTx(context.Background(), CompleteMfaChallengeTxParams{
+		TokenHash: tokenHash,
+		VerifyCode: func(credential TotpCredential) (int64, error) {
+			return 0, errors.New("wrong code")
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+	challenge, err := testQueries.GetMfaChallengeForUpdate(context.Background(), tokenHash)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), challenge.Attempts)
+
+	// the step used to enable totp can't be replayed
+	_, err = store.CompleteMfaChallengeTx(context.Background(), CompleteMfaChallengeTxParams{
+		TokenHash: tokenHash,
+		VerifyCode: func(credential TotpCredential) (int64, error) {
+			return 100, nil
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+
+	loggedIn, err := store.CompleteMfaChallengeTx(context.Background(), CompleteMfaChallengeTxParams{
+		TokenHash: tokenHash,
+		VerifyCode: func(credential TotpCredential) (int64, error) {
+			return 101, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.UserID, loggedIn.UserID)
+
+	_, err = store.CompleteMfaChallengeTx(context.Background(), CompleteMfaChallengeTxParams{
+		TokenHash: tokenHash,
+		VerifyCode: func(credential TotpCredential) (int64, error) {
+			return 102, nil
+		},
+	})
+	require.ErrorIs(t, err, ErrMfaChallengeUsed)
+}
+
+func TestCompleteMfaChallengeTxRecoveryCode(t *testing.T) {
+	store := NewStore(testDb)
+	user := createTestUser(t)
+	recoveryCodeHash := util.HashSecretToken(util.RandomStr(20))
+	enableTestTOTP(t, user, []string{recoveryCodeHash})
+
+	loggedIn, err := store.CompleteMfaChallengeTx(context.Background(), CompleteMfaChallengeTxParams{
+		TokenHash:        createTestMfaChallenge(t, user),
+		RecoveryCodeHash: recoveryCodeHash,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.UserID, loggedIn.UserID)
+
+	// a recovery code only works once
+	_, err = store.CompleteMfaChallengeTx(context.Background(), CompleteMfaChallengeTxParams{
+		TokenHash:        createTestMfaChallenge(t, user),
+		RecoveryCodeHash: recoveryCodeHash,
+	})
+	require.ErrorIs(t, err, ErrInvalidMfaCode)
+}
@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxMfaChallengeAttempts is the number of wrong codes after which a login
+// challenge can't be completed anymore.
+const MaxMfaChallengeAttempts = 5
+
+var (
+	ErrTOTPAlreadyEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnabled      = errors.New("two-factor authentication isn't enabled")
+	ErrInvalidMfaCode      = errors.New("invalid two-factor authentication code")
+	ErrMfaChallengeUsed    = errors.New("login challenge was already completed or replaced")
+	ErrMfaChallengeExpired = errors.New("login challenge has expired")
+	ErrMfaChallengeTooMany = errors.New("too many wrong codes for this login challenge")
+)
+
+type EnableTOTPTxParams struct {
+	UserID int64 `json:"user_id"`
+	// VerifyCode checks the first code of the user against the credential
+	// and returns the time step it matched.
+	VerifyCode         func(credential TotpCredential) (int64, error)
+	RecoveryCodeHashes []string `json:"recovery_code_hashes"`
+}
+
+// EnableTOTPTx turns on two-factor authentication once the user proved
+// their device holds the secret, replacing any previous recovery codes.
+func (store *StoreSQL) EnableTOTPTx(ctx context.Context, arg EnableTOTPTxParams) (TotpCredential, error) {
+	var result TotpCredential
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		credential, err := q.GetTotpCredentialForUpdate(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+		if credential.IsEnabled {
+			return ErrTOTPAlreadyEnabled
+		}
+		step, err := arg.VerifyCode(credential)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMfaCode, err)
+		}
+
+		result, err = q.EnableTotpCredential(ctx, EnableTotpCredentialParams{
+			LastUsedStep: step,
+			UserID:       arg.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteMfaRecoveryCodes(ctx, arg.UserID); err != nil {
+			return err
+		}
+		for _, codeHash := range arg.RecoveryCodeHashes {
+			_, err := q.CreateMfaRecoveryCode(ctx, CreateMfaRecoveryCodeParams{
+				UserID:   arg.UserID,
+				CodeHash: codeHash,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// StartMfaChallengeTx issues a login challenge and retires the ones the user
+// still had open, so a new LoginUser doesn't add to the codes an attacker
+// holding the password can try.
+func (store *StoreSQL) StartMfaChallengeTx(ctx context.Context, arg CreateMfaChallengeParams) (MfaChallenge, error) {
+	var result MfaChallenge
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.InvalidateMfaChallenges(ctx, arg.UserID); err != nil {
+			return err
+		}
+		var err error
+		result, err = q.CreateMfaChallenge(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// CompleteMfaChallengeTxParams holds either a TOTP code, checked by
+// VerifyCode, or the hash of a recovery code.
+type CompleteMfaChallengeTxParams struct {
+	TokenHash        string `json:"token_hash"`
+	RecoveryCodeHash string `json:"recovery_code_hash"`
+	// VerifyCode checks the TOTP code against the credential and returns
+	// the time step it matched.
+	VerifyCode func(credential TotpCredential) (int64, error)
+}
+
+// CompleteMfaChallengeTx finishes a login started by LoginUser for a user
+// with two-factor authentication and returns that user. A wrong code counts
+// as an attempt on the challenge even though an error is returned.
+func (store *StoreSQL) CompleteMfaChallengeTx(ctx context.Context, arg CompleteMfaChallengeTxParams) (User, error) {
+	var result User
+	var verifyErr error
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		challenge, err := q.GetMfaChallengeForUpdate(ctx, arg.TokenHash)
+		if err != nil {
+			return err
+		}
+		if challenge.IsUsed {
+			return ErrMfaChallengeUsed
+		}
+		if time.Now().After(challenge.ExpiredAt) {
+			return ErrMfaChallengeExpired
+		}
+		if challenge.Attempts >= MaxMfaChallengeAttempts {
+			return ErrMfaChallengeTooMany
+		}
+
+		credential, err := q.GetTotpCredentialForUpdate(ctx, challenge.UserID)
+		if err != nil {
+			return err
+		}
+		if !credential.IsEnabled {
+			return ErrTOTPNotEnabled
+		}
+
+		if arg.RecoveryCodeHash != "" {
+			_, err = q.UseMfaRecoveryCode(ctx, UseMfaRecoveryCodeParams{
+				UserID:   challenge.UserID,
+				CodeHash: arg.RecoveryCodeHash,
+			})
+			if err == sql.ErrNoRows {
+				verifyErr = fmt.Errorf("%w: unknown recovery code", ErrInvalidMfaCode)
+			} else if err != nil {
+				return err
+			}
+		} else {
+			step, err := arg.VerifyCode(credential)
+			switch {
+			case err != nil:
+				verifyErr = fmt.Errorf("%w: %v", ErrInvalidMfaCode, err)
+			case step <= credential.LastUsedStep:
+				verifyErr = fmt.Errorf("%w: code was already used", ErrInvalidMfaCode)
+			default:
+				_, err = q.UpdateTotpLastUsedStep(ctx, UpdateTotpLastUsedStepParams{
+					LastUsedStep: step,
+					UserID:       challenge.UserID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if verifyErr != nil {
+			// the attempt has to be committed, the error is reported below
+			_, err = q.IncrementMfaChallengeAttempts(ctx, challenge.ID)
+			return err
+		}
+
+		if _, err := q.MarkMfaChallengeUsed(ctx, challenge.ID); err != nil {
+			return err
+		}
+		result, err = q.GetUser(ctx, challenge.UserID)
+		return err
+	})
+	if err == nil && verifyErr != nil {
+		err = verifyErr
+	}
+
+	return result, err
+}
@@ -5,13 +5,146 @@
 package db
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AccountStatus string
+
+const (
+	AccountStatusActive AccountStatus = "active"
+	AccountStatusFrozen AccountStatus = "frozen"
+	AccountStatusClosed AccountStatus = "closed"
+)
+
+func (e *AccountStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccountStatus(s)
+	case string:
+		*e = AccountStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccountStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAccountStatus struct {
+	AccountStatus AccountStatus `json:"account_status"`
+	Valid         bool          `json:"valid"` // Valid is true if AccountStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccountStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccountStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccountStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccountStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccountStatus), nil
+}
+
+type ScheduledTransferAttemptStatus string
+
+const (
+	ScheduledTransferAttemptStatusPending   ScheduledTransferAttemptStatus = "pending"
+	ScheduledTransferAttemptStatusSucceeded ScheduledTransferAttemptStatus = "succeeded"
+	ScheduledTransferAttemptStatusFailed    ScheduledTransferAttemptStatus = "failed"
+	ScheduledTransferAttemptStatusSkipped   ScheduledTransferAttemptStatus = "skipped"
+)
+
+func (e *ScheduledTransferAttemptStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduledTransferAttemptStatus(s)
+	case string:
+		*e = ScheduledTransferAttemptStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduledTransferAttemptStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduledTransferAttemptStatus struct {
+	ScheduledTransferAttemptStatus ScheduledTransferAttemptStatus `json:"scheduled_transfer_attempt_status"`
+	Valid                          bool                           `json:"valid"` // Valid is true if ScheduledTransferAttemptStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduledTransferAttemptStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduledTransferAttemptStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduledTransferAttemptStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduledTransferAttemptStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduledTransferAttemptStatus), nil
+}
+
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferStatusActive    ScheduledTransferStatus = "active"
+	ScheduledTransferStatusPaused    ScheduledTransferStatus = "paused"
+	ScheduledTransferStatusCompleted ScheduledTransferStatus = "completed"
+	ScheduledTransferStatusDisabled  ScheduledTransferStatus = "disabled"
+)
+
+func (e *ScheduledTransferStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduledTransferStatus(s)
+	case string:
+		*e = ScheduledTransferStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduledTransferStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduledTransferStatus struct {
+	ScheduledTransferStatus ScheduledTransferStatus `json:"scheduled_transfer_status"`
+	Valid                   bool                    `json:"valid"` // Valid is true if ScheduledTransferStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduledTransferStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduledTransferStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduledTransferStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduledTransferStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduledTransferStatus), nil
+}
+
 type UserRole string
 
 const (
@@ -61,6 +194,38 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// how far below zero the balance may go
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// no money moves in or out of a frozen or closed account, only admins freeze accounts
+	Status AccountStatus `json:"status"`
+	// set when the owner closes the account, closed accounts are never deleted
+	ClosedAt sql.NullTime `json:"closed_at"`
+}
+
+type ApiKey struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+	// first characters of the key, shown to tell keys apart
+	KeyPrefix string `json:"key_prefix"`
+	// sha256 of the key, the key itself is only shown once
+	KeyHash string `json:"key_hash"`
+	// what the key may do on behalf of its user
+	Permissions []string     `json:"permissions"`
+	ExpiredAt   time.Time    `json:"expired_at"`
+	RevokedAt   sql.NullTime `json:"revoked_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type AuditLog struct {
+	ID int64 `json:"id"`
+	// admin who performed the action
+	ActorID    int64           `json:"actor_id"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Details    json.RawMessage `json:"details"`
+	CreatedAt  time.Time       `json:"created_at"`
 }
 
 type Entry struct {
@@ -69,6 +234,123 @@ type Entry struct {
 	Amount    int64     `json:"amount"`
 	AccountID int64     `json:"account_id"`
 	CreatedAt time.Time `json:"created_at"`
+	// transfer that produced the entry, null for other movements
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// account balance right after the entry was applied
+	BalanceAfter int64 `json:"balance_after"`
+}
+
+type ExternalIdentity struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"user_id"`
+	Issuer string `json:"issuer"`
+	// sub claim of the id token, unique per issuer
+	Subject string `json:"subject"`
+	// verified email of the id token when the identity was linked
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	UserID         int64           `json:"user_id"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	RequestHash    string          `json:"request_hash"`
+	TransferID     sql.NullInt64   `json:"transfer_id"`
+	Response       json.RawMessage `json:"response"`
+	CreatedAt      time.Time       `json:"created_at"`
+	ExpiredAt      time.Time       `json:"expired_at"`
+}
+
+type LoginAttempt struct {
+	// email or ip
+	Scope string `json:"scope"`
+	// lowercased email or client ip the failed logins came from
+	Identifier   string    `json:"identifier"`
+	FailedCount  int32     `json:"failed_count"`
+	LastFailedAt time.Time `json:"last_failed_at"`
+	// logins for this scope and identifier are refused until then
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+type MfaChallenge struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// sha256 of the challenge token returned by LoginUser
+	TokenHash string    `json:"token_hash"`
+	Attempts  int32     `json:"attempts"`
+	IsUsed    bool      `json:"is_used"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+type MfaRecoveryCode struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// sha256 of the normalized recovery code
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type OidcState struct {
+	ID int64 `json:"id"`
+	// sha256 of the state sent to the identity provider
+	StateHash string `json:"state_hash"`
+	// PKCE verifier sent with the authorization code
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	IsUsed       bool      `json:"is_used"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiredAt    time.Time `json:"expired_at"`
+}
+
+type PasswordReset struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// sha256 of the token sent by email, the token itself is never stored
+	TokenHash string    `json:"token_hash"`
+	IsUsed    bool      `json:"is_used"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+type RolePermission struct {
+	Role UserRole `json:"role"`
+	// resource:action, required by the RPCs through their permission option
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64 `json:"id"`
+	Owner         int64 `json:"owner"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	Amount        int64 `json:"amount"`
+	// standard 5 field cron expression, evaluated in UTC
+	CronExpression sql.NullString `json:"cron_expression"`
+	// fixed interval between runs, used when cron_expression is null
+	IntervalSeconds sql.NullInt64           `json:"interval_seconds"`
+	NextRunAt       time.Time               `json:"next_run_at"`
+	EndAt           sql.NullTime            `json:"end_at"`
+	Status          ScheduledTransferStatus `json:"status"`
+	// insufficient funds failures since the last successful run
+	ConsecutiveFailures int32     `json:"consecutive_failures"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type ScheduledTransferAttempt struct {
+	ID                  int64                          `json:"id"`
+	ScheduledTransferID int64                          `json:"scheduled_transfer_id"`
+	ScheduledFor        time.Time                      `json:"scheduled_for"`
+	Status              ScheduledTransferAttemptStatus `json:"status"`
+	TransferID          sql.NullInt64                  `json:"transfer_id"`
+	FailureReason       string                         `json:"failure_reason"`
+	// failed tries of a pending attempt for reasons other than insufficient funds
+	Retries   int32     `json:"retries"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Session struct {
@@ -80,6 +362,23 @@ type Session struct {
 	IsBlocked    bool      `json:"is_blocked"`
 	ExpiredAt    time.Time `json:"expired_at"`
 	CreatedAt    time.Time `json:"created_at"`
+	// id of the session created at login, shared by every session rotated from it
+	FamilyID uuid.UUID `json:"family_id"`
+	// session whose refresh token was exchanged for this one
+	ParentID uuid.NullUUID `json:"parent_id"`
+	// refresh token was already exchanged, presenting it again blocks the family
+	IsUsed bool `json:"is_used"`
+}
+
+type TotpCredential struct {
+	UserID int64  `json:"user_id"`
+	Secret string `json:"secret"`
+	// false until the user confirms the secret with a first code
+	IsEnabled bool `json:"is_enabled"`
+	// time step of the last accepted code, codes of that step or older are refused
+	LastUsedStep int64        `json:"last_used_step"`
+	CreatedAt    time.Time    `json:"created_at"`
+	EnabledAt    sql.NullTime `json:"enabled_at"`
 }
 
 type Transfer struct {
@@ -89,6 +388,10 @@ type Transfer struct {
 	// must be positive number
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// units of the destination currency bought by one unit of the source currency
+	ExchangeRate string `json:"exchange_rate"`
+	// amount credited to the destination account in its own currency
+	ConvertedAmount int64 `json:"converted_amount"`
 }
 
 type User struct {
@@ -100,6 +403,10 @@ type User struct {
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 	IsEmailVerified   bool      `json:"is_email_verified"`
+	// new email waiting for confirmation, it replaces email once verified
+	PendingEmail sql.NullString `json:"pending_email"`
+	// set by an admin, a locked user can't log in
+	IsLocked bool `json:"is_locked"`
 }
 
 type VerifyEmail struct {
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDb)
+	user := createTestUser(t)
+	session := createTestSession(t, user)
+
+	token, err := util.NewSecretToken(32)
+	require.NoError(t, err)
+	passwordReset, err := testQueries.CreatePasswordReset(context.Background(), CreatePasswordResetParams{
+		UserID:    user.UserID,
+		TokenHash: util.HashSecretToken(token),
+	})
+	require.NoError(t, err)
+	require.False(t, passwordReset.IsUsed)
+
+	hashedPassword, err := util.HashPassword(util.RandomStr(12))
+	require.NoError(t, err)
+	arg := ResetPasswordTxParams{
+		TokenHash:      util.HashSecretToken(token),
+		HashedPassword: hashedPassword,
+	}
+	updated, err := store.ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, updated.HashedPassword)
+	require.True(t, updated.PasswordChangedAt.After(user.PasswordChangedAt))
+
+	blocked, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.Session.IsBlocked)
+	// the session lookup carries the change so old access tokens get refused
+	require.True(t, blocked.PasswordChangedAt.Equal(updated.PasswordChangedAt))
+
+	_, err = store.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrPasswordResetUsed)
+}
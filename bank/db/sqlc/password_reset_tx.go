@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrPasswordResetUsed    = errors.New("password reset token was already used")
+	ErrPasswordResetExpired = errors.New("password reset token has expired")
+)
+
+type ResetPasswordTxParams struct {
+	TokenHash      string `json:"token_hash"`
+	HashedPassword string `json:"hashed_password"`
+}
+
+// ResetPasswordTx sets a new password for the owner of a password reset
+// token. Every outstanding token of the user is used up and all of their
+// sessions are blocked.
+func (store *StoreSQL) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error) {
+	var result User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		passwordReset, err := q.GetPasswordResetForUpdate(ctx, arg.TokenHash)
+		if err != nil {
+			return err
+		}
+		if passwordReset.IsUsed {
+			return ErrPasswordResetUsed
+		}
+		if time.Now().After(passwordReset.ExpiredAt) {
+			return ErrPasswordResetExpired
+		}
+
+		if err := q.InvalidatePasswordResets(ctx, passwordReset.UserID); err != nil {
+			return err
+		}
+		result, err = q.UpdateUser(ctx, UpdateUserParams{
+			HashedPassword:    sql.NullString{String: arg.HashedPassword, Valid: true},
+			PasswordChangedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			UserID:            passwordReset.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		return q.BlockUserSessions(ctx, passwordReset.UserID)
+	})
+
+	return result, err
+}
@@ -11,30 +11,100 @@ import (
 )
 
 type Querier interface {
+	ApplyPendingEmail(ctx context.Context, userID int64) (User, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error
+	BlockUserSessions(ctx context.Context, userID int64) error
+	CompleteScheduledTransferAttempt(ctx context.Context, arg CompleteScheduledTransferAttemptParams) (ScheduledTransferAttempt, error)
+	CountAccounts(ctx context.Context, owner int64) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateExternalIdentity(ctx context.Context, arg CreateExternalIdentityParams) (ExternalIdentity, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateMfaChallenge(ctx context.Context, arg CreateMfaChallengeParams) (MfaChallenge, error)
+	CreateMfaRecoveryCode(ctx context.Context, arg CreateMfaRecoveryCodeParams) (MfaRecoveryCode, error)
+	CreateOidcState(ctx context.Context, arg CreateOidcStateParams) (OidcState, error)
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferAttempt(ctx context.Context, arg CreateScheduledTransferAttemptParams) (ScheduledTransferAttempt, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
 	DeleteAccount(ctx context.Context, id int64) error
 	DeleteEntry(ctx context.Context, id int64) error
+	DeleteLoginAttempt(ctx context.Context, arg DeleteLoginAttemptParams) error
+	DeleteMfaRecoveryCodes(ctx context.Context, userID int64) error
+	DeleteScheduledTransfer(ctx context.Context, id int64) error
 	DeleteTransfer(ctx context.Context, id int64) error
+	DisableAccountScheduledTransfers(ctx context.Context, accountID int64) error
+	EnableTotpCredential(ctx context.Context, arg EnableTotpCredentialParams) (TotpCredential, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
-	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetExternalIdentity(ctx context.Context, arg GetExternalIdentityParams) (ExternalIdentity, error)
+	GetIdempotencyKeyForUpdate(ctx context.Context, arg GetIdempotencyKeyForUpdateParams) (IdempotencyKey, error)
+	GetLatestVerifyEmail(ctx context.Context, userID int64) (VerifyEmail, error)
+	GetLoginAttempt(ctx context.Context, arg GetLoginAttemptParams) (LoginAttempt, error)
+	GetMfaChallengeForUpdate(ctx context.Context, tokenHash string) (MfaChallenge, error)
+	GetPasswordResetForUpdate(ctx context.Context, tokenHash string) (PasswordReset, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetSession(ctx context.Context, id uuid.UUID) (GetSessionRow, error)
+	GetSessionForUpdate(ctx context.Context, id uuid.UUID) (Session, error)
+	GetTotpCredential(ctx context.Context, userID int64) (TotpCredential, error)
+	GetTotpCredentialForUpdate(ctx context.Context, userID int64) (TotpCredential, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, userID int64) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserByMfaChallenge(ctx context.Context, tokenHash string) (User, error)
+	GetVerifyEmailForUpdate(ctx context.Context, id int64) (VerifyEmail, error)
+	IncrementMfaChallengeAttempts(ctx context.Context, id int64) (MfaChallenge, error)
+	InvalidateMfaChallenges(ctx context.Context, userID int64) error
+	InvalidatePasswordResets(ctx context.Context, userID int64) error
+	ListAccountEntries(ctx context.Context, arg ListAccountEntriesParams) ([]ListAccountEntriesRow, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListActiveSessions(ctx context.Context, userID int64) ([]Session, error)
+	ListApiKeys(ctx context.Context, userID int64) ([]ApiKey, error)
+	ListAuditLogsByTarget(ctx context.Context, arg ListAuditLogsByTargetParams) ([]AuditLog, error)
+	ListDueScheduledTransfersForUpdate(ctx context.Context, arg ListDueScheduledTransfersForUpdateParams) ([]ScheduledTransfer, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListPendingScheduledTransferAttempts(ctx context.Context, limit int32) ([]ListPendingScheduledTransferAttemptsRow, error)
+	ListRolePermissions(ctx context.Context) ([]RolePermission, error)
+	ListScheduledTransferAttempts(ctx context.Context, arg ListScheduledTransferAttemptsParams) ([]ScheduledTransferAttempt, error)
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	LockLogin(ctx context.Context, arg LockLoginParams) (LoginAttempt, error)
+	MarkMfaChallengeUsed(ctx context.Context, id int64) (MfaChallenge, error)
+	MarkSessionUsed(ctx context.Context, id uuid.UUID) (Session, error)
+	MarkVerifyEmailUsed(ctx context.Context, id int64) (VerifyEmail, error)
+	RecordFailedLogin(ctx context.Context, arg RecordFailedLoginParams) (LoginAttempt, error)
+	RecordScheduledTransferFailure(ctx context.Context, arg RecordScheduledTransferFailureParams) (ScheduledTransfer, error)
+	ResetIdempotencyKey(ctx context.Context, arg ResetIdempotencyKeyParams) (IdempotencyKey, error)
+	ResetScheduledTransferFailures(ctx context.Context, id int64) error
+	RetryScheduledTransferAttempt(ctx context.Context, arg RetryScheduledTransferAttemptParams) error
+	RevokeApiKey(ctx context.Context, id int64) (ApiKey, error)
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
+	SetUserLocked(ctx context.Context, arg SetUserLockedParams) (User, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
 	UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
 	UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error)
+	UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error)
+	UpdateTotpLastUsedStep(ctx context.Context, arg UpdateTotpLastUsedStepParams) (TotpCredential, error)
 	UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserPendingEmail(ctx context.Context, arg UpdateUserPendingEmailParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpsertTotpCredential(ctx context.Context, arg UpsertTotpCredentialParams) (TotpCredential, error)
+	UseMfaRecoveryCode(ctx context.Context, arg UseMfaRecoveryCodeParams) (MfaRecoveryCode, error)
+	UseOidcState(ctx context.Context, stateHash string) (OidcState, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,23 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRolePermissions(t *testing.T) {
+	rolePermissions, err := testQueries.ListRolePermissions(context.Background())
+	require.NoError(t, err)
+
+	granted := make(map[UserRole][]string)
+	for _, rolePermission := range rolePermissions {
+		granted[rolePermission.Role] = append(granted[rolePermission.Role], rolePermission.Permission)
+	}
+	require.Contains(t, granted[UserRoleUser], "accounts:read")
+	require.NotContains(t, granted[UserRoleUser], "users:admin")
+	require.Contains(t, granted[UserRoleAdmin], "users:admin")
+	require.Contains(t, granted[UserRoleAdmin], "accounts:read_any")
+	require.NotContains(t, granted[UserRoleUser], "accounts:read_any")
+}
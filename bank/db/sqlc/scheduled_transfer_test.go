@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestScheduledTransfer(t *testing.T, nextRunAt time.Time) ScheduledTransfer {
+	ac1 := createTestAccount(t)
+	ac2 := createTestAccount(t)
+
+	arg := CreateScheduledTransferParams{
+		Owner:           ac1.Owner,
+		FromAccountID:   ac1.ID,
+		ToAccountID:     ac2.ID,
+		Amount:          10,
+		IntervalSeconds: sql.NullInt64{Int64: 3600, Valid: true},
+		NextRunAt:       nextRunAt,
+	}
+	scheduledTransfer, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferStatusActive, scheduledTransfer.Status)
+	require.Zero(t, scheduledTransfer.ConsecutiveFailures)
+	return scheduledTransfer
+}
+
+func TestClaimScheduledTransfersTx(t *testing.T) {
+	store := NewStore(testDb)
+
+	nextRunAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	scheduledTransfer := createTestScheduledTransfer(t, nextRunAt)
+
+	attempts, err := store.ClaimScheduledTransfersTx(context.Background(), ClaimScheduledTransfersTxParams{
+		Now:   time.Now(),
+		Limit: 1000,
+		NextRunAt: func(schedule ScheduledTransfer) (time.Time, error) {
+			return schedule.NextRunAt.Add(time.Hour), nil
+		},
+	})
+	require.NoError(t, err)
+
+	var claimed *ScheduledTransferAttempt
+	for i := range attempts {
+		if attempts[i].ScheduledTransferID == scheduledTransfer.ID {
+			claimed = &attempts[i]
+		}
+	}
+	require.NotNil(t, claimed)
+	require.Equal(t, ScheduledTransferAttemptStatusPending, claimed.Status)
+	require.WithinDuration(t, nextRunAt, claimed.ScheduledFor, time.Second)
+
+	updated, err := testQueries.GetScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.WithinDuration(t, nextRunAt.Add(time.Hour), updated.NextRunAt, time.Second)
+}
+
+func TestCompleteScheduledTransferAttemptTxDisablesSchedule(t *testing.T) {
+	store := NewStore(testDb)
+
+	scheduledTransfer := createTestScheduledTransfer(t, time.Now().Add(time.Hour))
+
+	for i := 1; i <= 3; i++ {
+		attempt, err := testQueries.CreateScheduledTransferAttempt(context.Background(), CreateScheduledTransferAttemptParams{
+			ScheduledTransferID: scheduledTransfer.ID,
+			ScheduledFor:        time.Now().Add(time.Duration(i) * time.Hour),
+		})
+		require.NoError(t, err)
+
+		arg := CompleteScheduledTransferAttemptTxParams{
+			CompleteScheduledTransferAttemptParams: CompleteScheduledTransferAttemptParams{
+				ID:            attempt.ID,
+				Status:        ScheduledTransferAttemptStatusFailed,
+				FailureReason: ErrInsufficientFunds.Error(),
+			},
+			ScheduledTransferID: scheduledTransfer.ID,
+			InsufficientFunds:   true,
+			MaxFailures:         3,
+		}
+		completed, err := store.CompleteScheduledTransferAttemptTx(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, ScheduledTransferAttemptStatusFailed, completed.Status)
+
+		// an attempt is only completed once
+		_, err = store.CompleteScheduledTransferAttemptTx(context.Background(), arg)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	}
+
+	updated, err := testQueries.GetScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), updated.ConsecutiveFailures)
+	require.Equal(t, ScheduledTransferStatusDisabled, updated.Status)
+}
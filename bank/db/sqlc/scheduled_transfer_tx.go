@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type UpdateScheduledTransferTxParams struct {
+	ID int64
+	// Update changes the locked schedule in place, returning an error aborts
+	// the update.
+	Update func(schedule *ScheduledTransfer) error
+}
+
+// UpdateScheduledTransferTx applies a read-modify-write change to a schedule
+// while holding its row lock, so it can't race with a run being claimed.
+func (store *StoreSQL) UpdateScheduledTransferTx(ctx context.Context, arg UpdateScheduledTransferTxParams) (ScheduledTransfer, error) {
+	var result ScheduledTransfer
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		schedule, err := q.GetScheduledTransferForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if err := arg.Update(&schedule); err != nil {
+			return err
+		}
+		result, err = q.UpdateScheduledTransfer(ctx, updateScheduledTransferParams(schedule))
+		return err
+	})
+
+	return result, err
+}
+
+type ClaimScheduledTransfersTxParams struct {
+	Now   time.Time
+	Limit int32
+	// NextRunAt returns the run following the claimed one.
+	NextRunAt func(schedule ScheduledTransfer) (time.Time, error)
+}
+
+// ClaimScheduledTransfersTx turns every due run of an active schedule into a
+// pending attempt and moves the schedule on to its next run, completing it
+// once the end date is passed. Schedules locked by another worker are skipped.
+func (store *StoreSQL) ClaimScheduledTransfersTx(ctx context.Context, arg ClaimScheduledTransfersTxParams) ([]ScheduledTransferAttempt, error) {
+	attempts := []ScheduledTransferAttempt{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		schedules, err := q.ListDueScheduledTransfersForUpdate(ctx, ListDueScheduledTransfersForUpdateParams{
+			Now:        arg.Now,
+			LimitCount: arg.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, schedule := range schedules {
+			if schedule.EndAt.Valid && schedule.NextRunAt.After(schedule.EndAt.Time) {
+				schedule.Status = ScheduledTransferStatusCompleted
+				if _, err := q.UpdateScheduledTransfer(ctx, updateScheduledTransferParams(schedule)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			attempt, err := q.CreateScheduledTransferAttempt(ctx, CreateScheduledTransferAttemptParams{
+				ScheduledTransferID: schedule.ID,
+				ScheduledFor:        schedule.NextRunAt,
+			})
+			switch {
+			case err == nil:
+				attempts = append(attempts, attempt)
+			case err != sql.ErrNoRows:
+				// sql.ErrNoRows means the run was already claimed
+				return err
+			}
+
+			schedule.NextRunAt, err = arg.NextRunAt(schedule)
+			if err != nil {
+				return err
+			}
+			if schedule.EndAt.Valid && schedule.NextRunAt.After(schedule.EndAt.Time) {
+				schedule.Status = ScheduledTransferStatusCompleted
+			}
+			if _, err := q.UpdateScheduledTransfer(ctx, updateScheduledTransferParams(schedule)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return attempts, err
+}
+
+type CompleteScheduledTransferAttemptTxParams struct {
+	CompleteScheduledTransferAttemptParams
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+	// InsufficientFunds counts a failed attempt towards MaxFailures, the
+	// schedule is disabled once it has failed that many times in a row.
+	InsufficientFunds bool  `json:"insufficient_funds"`
+	MaxFailures       int32 `json:"max_failures"`
+}
+
+// CompleteScheduledTransferAttemptTx records the outcome of a pending attempt
+// and keeps the schedule's failure count in step. It returns sql.ErrNoRows
+// when the attempt was already completed by another worker.
+func (store *StoreSQL) CompleteScheduledTransferAttemptTx(ctx context.Context, arg CompleteScheduledTransferAttemptTxParams) (ScheduledTransferAttempt, error) {
+	var result ScheduledTransferAttempt
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.CompleteScheduledTransferAttempt(ctx, arg.CompleteScheduledTransferAttemptParams)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case result.Status == ScheduledTransferAttemptStatusSucceeded:
+			return q.ResetScheduledTransferFailures(ctx, arg.ScheduledTransferID)
+		case result.Status == ScheduledTransferAttemptStatusFailed && arg.InsufficientFunds:
+			_, err = q.RecordScheduledTransferFailure(ctx, RecordScheduledTransferFailureParams{
+				MaxFailures: arg.MaxFailures,
+				ID:          arg.ScheduledTransferID,
+			})
+			return err
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func updateScheduledTransferParams(schedule ScheduledTransfer) UpdateScheduledTransferParams {
+	return UpdateScheduledTransferParams{
+		ID:                  schedule.ID,
+		Amount:              schedule.Amount,
+		CronExpression:      schedule.CronExpression,
+		IntervalSeconds:     schedule.IntervalSeconds,
+		NextRunAt:           schedule.NextRunAt,
+		EndAt:               schedule.EndAt,
+		Status:              schedule.Status,
+		ConsecutiveFailures: schedule.ConsecutiveFailures,
+	}
+}
@@ -12,23 +12,47 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockSessionFamily = `-- name: BlockSessionFamily :exec
+UPDATE sessions
+  set is_blocked = true
+WHERE family_id = $1
+`
+
+func (q *Queries) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, blockSessionFamily, familyID)
+	return err
+}
+
+const blockUserSessions = `-- name: BlockUserSessions :exec
+UPDATE sessions
+  set is_blocked = true
+WHERE user_id = $1 AND is_blocked = false
+`
+
+func (q *Queries) BlockUserSessions(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, blockUserSessions, userID)
+	return err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
-   id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at
+   id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at, family_id, parent_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
-RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at
+RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, family_id, parent_id, is_used
 `
 
 type CreateSessionParams struct {
-	ID           uuid.UUID `json:"id"`
-	UserID       int64     `json:"user_id"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiredAt    time.Time `json:"expired_at"`
+	ID           uuid.UUID     `json:"id"`
+	UserID       int64         `json:"user_id"`
+	RefreshToken string        `json:"refresh_token"`
+	UserAgent    string        `json:"user_agent"`
+	ClientIp     string        `json:"client_ip"`
+	IsBlocked    bool          `json:"is_blocked"`
+	ExpiredAt    time.Time     `json:"expired_at"`
+	FamilyID     uuid.UUID     `json:"family_id"`
+	ParentID     uuid.NullUUID `json:"parent_id"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
@@ -40,6 +64,8 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		arg.ClientIp,
 		arg.IsBlocked,
 		arg.ExpiredAt,
+		arg.FamilyID,
+		arg.ParentID,
 	)
 	var i Session
 	err := row.Scan(
@@ -51,17 +77,122 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.IsBlocked,
 		&i.ExpiredAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.ParentID,
+		&i.IsUsed,
 	)
 	return i, err
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at FROM sessions
-WHERE id = $1 LIMIT 1
+SELECT sessions.id, sessions.user_id, sessions.refresh_token, sessions.user_agent, sessions.client_ip, sessions.is_blocked, sessions.expired_at, sessions.created_at, sessions.family_id, sessions.parent_id, sessions.is_used, users.password_changed_at FROM sessions
+JOIN users ON users.user_id = sessions.user_id
+WHERE sessions.id = $1 LIMIT 1
 `
 
-func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (Session, error) {
+type GetSessionRow struct {
+	Session           Session   `json:"session"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+}
+
+func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (GetSessionRow, error) {
 	row := q.db.QueryRowContext(ctx, getSession, id)
+	var i GetSessionRow
+	err := row.Scan(
+		&i.Session.ID,
+		&i.Session.UserID,
+		&i.Session.RefreshToken,
+		&i.Session.UserAgent,
+		&i.Session.ClientIp,
+		&i.Session.IsBlocked,
+		&i.Session.ExpiredAt,
+		&i.Session.CreatedAt,
+		&i.Session.FamilyID,
+		&i.Session.ParentID,
+		&i.Session.IsUsed,
+		&i.PasswordChangedAt,
+	)
+	return i, err
+}
+
+const getSessionForUpdate = `-- name: GetSessionForUpdate :one
+SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, family_id, parent_id, is_used FROM sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetSessionForUpdate(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSessionForUpdate, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiredAt,
+		&i.CreatedAt,
+		&i.FamilyID,
+		&i.ParentID,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const listActiveSessions = `-- name: ListActiveSessions :many
+SELECT id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, family_id, parent_id, is_used FROM sessions
+WHERE user_id = $1
+  AND is_blocked = false
+  AND is_used = false
+  AND expired_at > now()
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListActiveSessions(ctx context.Context, userID int64) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiredAt,
+			&i.CreatedAt,
+			&i.FamilyID,
+			&i.ParentID,
+			&i.IsUsed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markSessionUsed = `-- name: MarkSessionUsed :one
+UPDATE sessions
+  set is_used = true
+WHERE id = $1
+RETURNING id, user_id, refresh_token, user_agent, client_ip, is_blocked, expired_at, created_at, family_id, parent_id, is_used
+`
+
+func (q *Queries) MarkSessionUsed(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.db.QueryRowContext(ctx, markSessionUsed, id)
 	var i Session
 	err := row.Scan(
 		&i.ID,
@@ -72,6 +203,9 @@ func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 		&i.IsBlocked,
 		&i.ExpiredAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.ParentID,
+		&i.IsUsed,
 	)
 	return i, err
 }
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSession(t *testing.T, user User) Session {
+	id := uuid.New()
+	arg := CreateSessionParams{
+		ID:           id,
+		UserID:       user.UserID,
+		RefreshToken: util.RandomStr(32),
+		UserAgent:    "test",
+		ClientIp:     "127.0.0.1",
+		ExpiredAt:    time.Now().Add(time.Hour),
+		FamilyID:     id,
+	}
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.FamilyID, session.FamilyID)
+	require.False(t, session.ParentID.Valid)
+	require.False(t, session.IsUsed)
+	return session
+}
+
+func rotateTestSession(store Store, session Session) (Session, error) {
+	return store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		SessionID:    session.ID,
+		RefreshToken: session.RefreshToken,
+		NewSession: func(old Session) (CreateSessionParams, error) {
+			return CreateSessionParams{
+				ID:           uuid.New(),
+				UserID:       old.UserID,
+				RefreshToken: util.RandomStr(32),
+				UserAgent:    old.UserAgent,
+				ClientIp:     old.ClientIp,
+				ExpiredAt:    time.Now().Add(time.Hour),
+			}, nil
+		},
+	})
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	store := NewStore(testDb)
+	session := createTestSession(t, createTestUser(t))
+
+	rotated, err := rotateTestSession(store, session)
+	require.NoError(t, err)
+	require.NotEqual(t, session.ID, rotated.ID)
+	require.Equal(t, session.FamilyID, rotated.FamilyID)
+	require.Equal(t, session.ID, rotated.ParentID.UUID)
+	require.False(t, rotated.IsUsed)
+
+	old, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, old.Session.IsUsed)
+
+	// a second exchange of the same token means it leaked
+	_, err = rotateTestSession(store, session)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	blocked, err := testQueries.GetSession(context.Background(), rotated.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.Session.IsBlocked)
+
+	_, err = rotateTestSession(store, rotated)
+	require.ErrorIs(t, err, ErrSessionBlocked)
+}
+
+func TestRotateSessionTxTokenMismatch(t *testing.T) {
+	store := NewStore(testDb)
+	session := createTestSession(t, createTestUser(t))
+	session.RefreshToken = util.RandomStr(32)
+
+	_, err := rotateTestSession(store, session)
+	require.ErrorIs(t, err, ErrSessionTokenMismatch)
+}
+
+func TestListActiveSessions(t *testing.T) {
+	store := NewStore(testDb)
+	user := createTestUser(t)
+	session1 := createTestSession(t, user)
+	session2 := createTestSession(t, user)
+
+	// a rotated session is replaced by its child in the list
+	rotated, err := rotateTestSession(store, session2)
+	require.NoError(t, err)
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), user.UserID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	ids := []uuid.UUID{sessions[0].ID, sessions[1].ID}
+	require.Contains(t, ids, session1.ID)
+	require.Contains(t, ids, rotated.ID)
+
+	err = testQueries.BlockUserSessions(context.Background(), user.UserID)
+	require.NoError(t, err)
+
+	sessions, err = testQueries.ListActiveSessions(context.Background(), user.UserID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
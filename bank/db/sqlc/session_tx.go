@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionBlocked       = errors.New("blocked session")
+	ErrSessionExpired       = errors.New("expired session")
+	ErrSessionTokenMismatch = errors.New("mismatched session token")
+	ErrRefreshTokenReused   = errors.New("refresh token was already used, all sessions of this login were revoked")
+)
+
+type RotateSessionTxParams struct {
+	SessionID    uuid.UUID `json:"session_id"`
+	RefreshToken string    `json:"refresh_token"`
+	// NewSession builds the session that replaces the presented one, the
+	// family and parent are filled in by RotateSessionTx.
+	NewSession func(session Session) (CreateSessionParams, error)
+}
+
+// RotateSessionTx exchanges a refresh token for a new session, marking the old
+// one used. Presenting a refresh token that was already exchanged means it
+// leaked, so every session of its family is blocked and ErrRefreshTokenReused
+// is returned.
+func (store *StoreSQL) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error) {
+	var result Session
+	reused := false
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		session, err := q.GetSessionForUpdate(ctx, arg.SessionID)
+		if err != nil {
+			return err
+		}
+		if session.RefreshToken != arg.RefreshToken {
+			return ErrSessionTokenMismatch
+		}
+		if session.IsUsed {
+			// the block has to be committed, the error is reported below
+			reused = true
+			return q.BlockSessionFamily(ctx, session.FamilyID)
+		}
+		if session.IsBlocked {
+			return ErrSessionBlocked
+		}
+		if time.Now().After(session.ExpiredAt) {
+			return ErrSessionExpired
+		}
+
+		if _, err := q.MarkSessionUsed(ctx, session.ID); err != nil {
+			return err
+		}
+		newSession, err := arg.NewSession(session)
+		if err != nil {
+			return err
+		}
+		newSession.FamilyID = session.FamilyID
+		newSession.ParentID = uuid.NullUUID{UUID: session.ID, Valid: true}
+		result, err = q.CreateSession(ctx, newSession)
+		return err
+	})
+	if err == nil && reused {
+		err = ErrRefreshTokenReused
+	}
+
+	return result, err
+}
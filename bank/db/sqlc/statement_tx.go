@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type AccountStatementTxParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+type AccountStatementTxResult struct {
+	Account        Account                   `json:"account"`
+	OpeningBalance int64                     `json:"opening_balance"`
+	Entries        []ListStatementEntriesRow `json:"entries"`
+}
+
+// AccountStatementTx reads the account, its balance at FromTime and the
+// entries of [FromTime, ToTime) from a single snapshot so the opening balance
+// and the entries always add up.
+func (store *StoreSQL) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+	var result AccountStatementTxResult
+
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := store.execTxWithOptions(ctx, opts, func(q *Queries) error {
+		var err error
+		result.Account, err = q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		result.OpeningBalance, err = q.GetAccountBalanceAt(ctx, GetAccountBalanceAtParams{
+			At:        arg.FromTime,
+			AccountID: arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+		result.Entries, err = q.ListStatementEntries(ctx, ListStatementEntriesParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.FromTime,
+			ToTime:    arg.ToTime,
+		})
+		return err
+	})
+
+	return result, err
+}
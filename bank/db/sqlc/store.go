@@ -9,7 +9,22 @@ import (
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	IdempotentTransferTx(ctx context.Context, arg IdempotentTransferTxParams) (TransferTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	ChangeEmailTx(ctx context.Context, arg ChangeEmailTxParams) (User, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
+	UpdateScheduledTransferTx(ctx context.Context, arg UpdateScheduledTransferTxParams) (ScheduledTransfer, error)
+	ClaimScheduledTransfersTx(ctx context.Context, arg ClaimScheduledTransfersTxParams) ([]ScheduledTransferAttempt, error)
+	CompleteScheduledTransferAttemptTx(ctx context.Context, arg CompleteScheduledTransferAttemptTxParams) (ScheduledTransferAttempt, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error)
+	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error)
+	EnableTOTPTx(ctx context.Context, arg EnableTOTPTxParams) (TotpCredential, error)
+	StartMfaChallengeTx(ctx context.Context, arg CreateMfaChallengeParams) (MfaChallenge, error)
+	CompleteMfaChallengeTx(ctx context.Context, arg CompleteMfaChallengeTxParams) (User, error)
+	LoginExternalIdentityTx(ctx context.Context, arg LoginExternalIdentityTxParams) (User, error)
+	AdminActionTx(ctx context.Context, arg AdminActionTxParams) (AuditLog, error)
+	CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error)
 }
 type StoreSQL struct {
 	*Queries
@@ -28,7 +43,11 @@ func NewStore(db *sql.DB) Store {
 }
 
 func (store *StoreSQL) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+	return store.execTxWithOptions(ctx, nil, fn)
+}
+
+func (store *StoreSQL) execTxWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -44,10 +63,16 @@ func (store *StoreSQL) execTx(ctx context.Context, fn func(*Queries) error) erro
 	return tx.Commit()
 }
 
+// TransferTxParams describes a transfer of Amount in the source account's
+// currency. For transfers between currencies ConvertedAmount is credited to
+// the destination account at ExchangeRate, when ExchangeRate is empty both
+// accounts share a currency and Amount is credited as is.
 type TransferTxParams struct {
-	FromAccountId int64 `json:"from_account_id"`
-	ToAccountId   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountId   int64  `json:"from_account_id"`
+	ToAccountId     int64  `json:"to_account_id"`
+	Amount          int64  `json:"amount"`
+	ExchangeRate    string `json:"exchange_rate"`
+	ConvertedAmount int64  `json:"converted_amount"`
 }
 type TransferTxResult struct {
 	Transfer    Transfer `json:"transfer"`
@@ -62,67 +87,110 @@ func (store *StoreSQL) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = transfer(ctx, q, arg)
+		return err
+	})
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountId,
-			ToAccountID:   arg.ToAccountId,
-			Amount:        arg.Amount,
-		})
+	return result, err
+}
+
+// transfer moves money between two accounts using the queries of an already
+// open transaction.
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
 
+	exchangeRate, creditAmount := arg.ExchangeRate, arg.ConvertedAmount
+	if exchangeRate == "" {
+		exchangeRate, creditAmount = "1", arg.Amount
+	}
+
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID:   arg.FromAccountId,
+		ToAccountID:     arg.ToAccountId,
+		Amount:          arg.Amount,
+		ExchangeRate:    exchangeRate,
+		ConvertedAmount: creditAmount,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	if arg.FromAccountId < arg.ToAccountId {
+		result.FromAccount, err = debitAccount(ctx, q, arg.FromAccountId, arg.Amount)
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			Amount:    -arg.Amount,
-			AccountID: arg.FromAccountId,
+		result.ToAccount, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
+			Amount: creditAmount,
+			ID:     arg.ToAccountId,
 		})
 		if err != nil {
-			return err
+			return result, err
 		}
-
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			Amount:    arg.Amount,
-			AccountID: arg.ToAccountId,
+	} else {
+		result.ToAccount, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
+			Amount: creditAmount,
+			ID:     arg.ToAccountId,
 		})
 		if err != nil {
-			return err
+			return result, err
 		}
-		if arg.FromAccountId < arg.ToAccountId {
-			result.FromAccount, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
-				Amount: -arg.Amount,
-				ID:     arg.FromAccountId,
-			})
-			if err != nil {
-				return err
-			}
-
-			result.ToAccount, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
-				Amount: arg.Amount,
-				ID:     arg.ToAccountId,
-			})
-			if err != nil {
-				return err
-			}
-		} else {
-			result.ToAccount, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
-				Amount: arg.Amount,
-				ID:     arg.ToAccountId,
-			})
-			if err != nil {
-				return err
-			}
-			result.FromAccount, err = q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
-				Amount: -arg.Amount,
-				ID:     arg.FromAccountId,
-			})
-			if err != nil {
-				return err
-			}
+		result.FromAccount, err = debitAccount(ctx, q, arg.FromAccountId, arg.Amount)
+		if err != nil {
+			return result, err
 		}
+	}
+	// the balance updates hold the row locks, an account can't be frozen
+	// or closed between this check and the commit
+	if err := checkAccountStatus(result.FromAccount); err != nil {
+		return result, err
+	}
+	if err := checkAccountStatus(result.ToAccount); err != nil {
+		return result, err
+	}
 
-		return nil
+	// the entries are written after the balance updates, under the same row
+	// locks, so entry ids follow the order the balances changed in
+	transferID := sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		Amount:       -arg.Amount,
+		AccountID:    arg.FromAccountId,
+		TransferID:   transferID,
+		BalanceAfter: result.FromAccount.Balance,
 	})
+	if err != nil {
+		return result, err
+	}
 
-	return result, err
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		Amount:       creditAmount,
+		AccountID:    arg.ToAccountId,
+		TransferID:   transferID,
+		BalanceAfter: result.ToAccount.Balance,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// debitAccount takes amount out of the account, failing with
+// ErrInsufficientFunds when that would push the balance below the account's
+// overdraft limit.
+func debitAccount(ctx context.Context, q *Queries, accountID int64, amount int64) (Account, error) {
+	account, err := q.UpdateAccountBalance(ctx, UpdateAccountBalanceParams{
+		Amount: -amount,
+		ID:     accountID,
+	})
+	if err != nil {
+		if isInsufficientFunds(err) {
+			return account, fmt.Errorf("account %d: %w", accountID, ErrInsufficientFunds)
+		}
+		return account, err
+	}
+	return account, nil
 }
@@ -3,7 +3,9 @@ package db
 import (
 	"context"
 	"fmt"
+	"main/util"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -146,3 +148,150 @@ func TestTxTransferDeadlock(t *testing.T) {
 	require.Equal(t, ac1.Balance, updatedAccount1.Balance)
 	require.Equal(t, ac2.Balance, updatedAccount2.Balance)
 }
+
+func TestIdempotentTransferTx(t *testing.T) {
+	store := NewStore(testDb)
+
+	ac1 := createTestAccount(t)
+	ac2 := createTestAccount(t)
+	arg := IdempotentTransferTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountId: ac1.ID,
+			ToAccountId:   ac2.ID,
+			Amount:        10,
+		},
+		UserID:         ac1.Owner,
+		IdempotencyKey: util.RandomStr(16),
+		RequestHash:    util.RandomStr(64),
+		Window:         time.Minute,
+	}
+
+	result1, err := store.IdempotentTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// a replay returns the first result without moving money again
+	result2, err := store.IdempotentTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, result1.Transfer.ID, result2.Transfer.ID)
+	require.Equal(t, result1.FromEntry.ID, result2.FromEntry.ID)
+	require.Equal(t, result1.ToEntry.ID, result2.ToEntry.ID)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), ac1.ID)
+	require.NoError(t, err)
+	require.Equal(t, ac1.Balance-arg.Amount, updatedAccount1.Balance)
+
+	// the same key with a different body is rejected
+	arg.Amount = 20
+	arg.RequestHash = util.RandomStr(64)
+	_, err = store.IdempotentTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyConflict)
+}
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDb)
+
+	ac1 := createTestAccount(t)
+	ac2 := createTestAccount(t)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountId: ac1.ID,
+		ToAccountId:   ac2.ID,
+		Amount:        ac1.Balance + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), ac1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := testQueries.GetAccount(context.Background(), ac2.ID)
+	require.NoError(t, err)
+	require.Equal(t, ac1.Balance, updatedAccount1.Balance)
+	require.Equal(t, ac2.Balance, updatedAccount2.Balance)
+
+	// an overdraft limit lets the balance go below zero down to the limit
+	_, err = testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		OverdraftLimit: 100,
+		ID:             ac1.ID,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountId: ac1.ID,
+		ToAccountId:   ac2.ID,
+		Amount:        ac1.Balance + 100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-100), result.FromAccount.Balance)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountId: ac1.ID,
+		ToAccountId:   ac2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestTransferTxExchangeRate(t *testing.T) {
+	store := NewStore(testDb)
+
+	ac1 := createTestAccount(t)
+	ac2 := createTestAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountId:   ac1.ID,
+		ToAccountId:     ac2.ID,
+		Amount:          1000,
+		ExchangeRate:    "0.9200000000",
+		ConvertedAmount: 920,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1000), result.Transfer.Amount)
+	require.Equal(t, "0.9200000000", result.Transfer.ExchangeRate)
+	require.Equal(t, int64(920), result.Transfer.ConvertedAmount)
+	require.Equal(t, int64(-1000), result.FromEntry.Amount)
+	require.Equal(t, int64(920), result.ToEntry.Amount)
+	require.Equal(t, ac1.Balance-1000, result.FromAccount.Balance)
+	require.Equal(t, ac2.Balance+920, result.ToAccount.Balance)
+
+	// without a rate the destination is credited the amount as is
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountId: ac1.ID,
+		ToAccountId:   ac2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1.0000000000", result.Transfer.ExchangeRate)
+	require.Equal(t, int64(10), result.Transfer.ConvertedAmount)
+	require.Equal(t, int64(10), result.ToEntry.Amount)
+}
+
+func TestAccountStatementTx(t *testing.T) {
+	store := NewStore(testDb)
+
+	ac1 := createTestAccount(t)
+	ac2 := createTestAccount(t)
+
+	fromTime := time.Now().Add(-time.Minute)
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountId: ac1.ID,
+		ToAccountId:   ac2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, transfer.Transfer.ID, transfer.FromEntry.TransferID.Int64)
+	require.Equal(t, transfer.Transfer.ID, transfer.ToEntry.TransferID.Int64)
+	require.Equal(t, transfer.FromAccount.Balance, transfer.FromEntry.BalanceAfter)
+	require.Equal(t, transfer.ToAccount.Balance, transfer.ToEntry.BalanceAfter)
+
+	result, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: ac1.ID,
+		FromTime:  fromTime,
+		ToTime:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ac1.ID, result.Account.ID)
+	require.Equal(t, ac1.Balance, result.OpeningBalance)
+	require.Len(t, result.Entries, 1)
+	require.Equal(t, int64(-10), result.Entries[0].Amount)
+	require.Equal(t, ac2.ID, result.Entries[0].ToAccountID.Int64)
+}
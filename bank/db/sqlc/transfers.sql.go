@@ -11,21 +11,29 @@ import (
 
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
-  from_account_id, to_account_id, amount
+  from_account_id, to_account_id, amount, exchange_rate, converted_amount
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 )
-RETURNING id, from_account_id, to_account_id, amount, created_at
+RETURNING id, from_account_id, to_account_id, amount, created_at, exchange_rate, converted_amount
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID   int64  `json:"from_account_id"`
+	ToAccountID     int64  `json:"to_account_id"`
+	Amount          int64  `json:"amount"`
+	ExchangeRate    string `json:"exchange_rate"`
+	ConvertedAmount int64  `json:"converted_amount"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ExchangeRate,
+		arg.ConvertedAmount,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -33,6 +41,8 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ExchangeRate,
+		&i.ConvertedAmount,
 	)
 	return i, err
 }
@@ -48,7 +58,7 @@ func (q *Queries) DeleteTransfer(ctx context.Context, id int64) error {
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, exchange_rate, converted_amount FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -61,12 +71,14 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ExchangeRate,
+		&i.ConvertedAmount,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, exchange_rate, converted_amount FROM transfers
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -92,6 +104,8 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ExchangeRate,
+			&i.ConvertedAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -110,7 +124,7 @@ const updateTransfer = `-- name: UpdateTransfer :one
 UPDATE transfers
   set amount = $2
 WHERE id = $1
-RETURNING id, from_account_id, to_account_id, amount, created_at
+RETURNING id, from_account_id, to_account_id, amount, created_at, exchange_rate, converted_amount
 `
 
 type UpdateTransferParams struct {
@@ -127,6 +141,8 @@ func (q *Queries) UpdateTransfer(ctx context.Context, arg UpdateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ExchangeRate,
+		&i.ConvertedAmount,
 	)
 	return i, err
 }
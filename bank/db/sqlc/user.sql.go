@@ -10,13 +10,40 @@ import (
 	"database/sql"
 )
 
+const applyPendingEmail = `-- name: ApplyPendingEmail :one
+UPDATE users
+  set email = pending_email,
+  pending_email = NULL,
+  is_email_verified = true
+WHERE user_id = $1 AND pending_email IS NOT NULL
+RETURNING user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked
+`
+
+func (q *Queries) ApplyPendingEmail(ctx context.Context, userID int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, applyPendingEmail, userID)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.Role,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.PendingEmail,
+		&i.IsLocked,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
    hashed_password, full_name, email, role
 ) VALUES (
   $1, $2, $3, $4
 )
-RETURNING user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified
+RETURNING user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked
 `
 
 type CreateUserParams struct {
@@ -43,12 +70,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.PendingEmail,
+		&i.IsLocked,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified FROM users
+SELECT user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked FROM users
 WHERE user_id = $1 LIMIT 1
 `
 
@@ -64,12 +93,14 @@ func (q *Queries) GetUser(ctx context.Context, userID int64) (User, error) {
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.PendingEmail,
+		&i.IsLocked,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified FROM users
+SELECT user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -85,6 +116,87 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.PendingEmail,
+		&i.IsLocked,
+	)
+	return i, err
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked FROM users
+WHERE email ILIKE '%' || replace(replace(replace($1::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\'
+  OR full_name ILIKE '%' || replace(replace(replace($1::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\'
+ORDER BY user_id
+LIMIT $2
+OFFSET $3
+`
+
+type SearchUsersParams struct {
+	Query       string `json:"query"`
+	LimitCount  int32  `json:"limit_count"`
+	OffsetCount int32  `json:"offset_count"`
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsers, arg.Query, arg.LimitCount, arg.OffsetCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.UserID,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Email,
+			&i.Role,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.IsEmailVerified,
+			&i.PendingEmail,
+			&i.IsLocked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserLocked = `-- name: SetUserLocked :one
+UPDATE users
+  set is_locked = $1
+WHERE user_id = $2
+RETURNING user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked
+`
+
+type SetUserLockedParams struct {
+	IsLocked bool  `json:"is_locked"`
+	UserID   int64 `json:"user_id"`
+}
+
+func (q *Queries) SetUserLocked(ctx context.Context, arg SetUserLockedParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, setUserLocked, arg.IsLocked, arg.UserID)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.Role,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.PendingEmail,
+		&i.IsLocked,
 	)
 	return i, err
 }
@@ -94,9 +206,10 @@ UPDATE users
   set email = coalesce($1, email),
   full_name = coalesce($2, full_name),
   hashed_password = coalesce($3, hashed_password),
-  password_changed_at = coalesce($4, password_changed_at)
-WHERE user_id = $5
-RETURNING user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified
+  password_changed_at = coalesce($4, password_changed_at),
+  is_email_verified = coalesce($5, is_email_verified)
+WHERE user_id = $6
+RETURNING user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked
 `
 
 type UpdateUserParams struct {
@@ -104,6 +217,7 @@ type UpdateUserParams struct {
 	FullName          sql.NullString `json:"full_name"`
 	HashedPassword    sql.NullString `json:"hashed_password"`
 	PasswordChangedAt sql.NullTime   `json:"password_changed_at"`
+	IsEmailVerified   sql.NullBool   `json:"is_email_verified"`
 	UserID            int64          `json:"user_id"`
 }
 
@@ -113,6 +227,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.FullName,
 		arg.HashedPassword,
 		arg.PasswordChangedAt,
+		arg.IsEmailVerified,
 		arg.UserID,
 	)
 	var i User
@@ -125,6 +240,68 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.PendingEmail,
+		&i.IsLocked,
+	)
+	return i, err
+}
+
+const updateUserPendingEmail = `-- name: UpdateUserPendingEmail :one
+UPDATE users
+  set pending_email = $1
+WHERE user_id = $2
+RETURNING user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked
+`
+
+type UpdateUserPendingEmailParams struct {
+	PendingEmail sql.NullString `json:"pending_email"`
+	UserID       int64          `json:"user_id"`
+}
+
+func (q *Queries) UpdateUserPendingEmail(ctx context.Context, arg UpdateUserPendingEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPendingEmail, arg.PendingEmail, arg.UserID)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.Role,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.PendingEmail,
+		&i.IsLocked,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+  set role = $1
+WHERE user_id = $2
+RETURNING user_id, hashed_password, full_name, email, role, password_changed_at, created_at, is_email_verified, pending_email, is_locked
+`
+
+type UpdateUserRoleParams struct {
+	Role   UserRole `json:"role"`
+	UserID int64    `json:"user_id"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserRole, arg.Role, arg.UserID)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.Role,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.PendingEmail,
+		&i.IsLocked,
 	)
 	return i, err
 }
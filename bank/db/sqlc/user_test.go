@@ -48,3 +48,81 @@ func TestGetUser(t *testing.T) {
 	require.WithinDuration(t, user.CreatedAt, user2.CreatedAt, time.Second)
 	require.WithinDuration(t, user.PasswordChangedAt, user2.PasswordChangedAt, time.Second)
 }
+
+func TestChangeEmailTx(t *testing.T) {
+	store := NewStore(testDb)
+	user := createTestUser(t)
+	other := createTestUser(t)
+	newEmail := util.RandomEmail()
+
+	_, err := store.ChangeEmailTx(context.Background(), ChangeEmailTxParams{
+		UserID:      user.UserID,
+		NewEmail:    other.Email,
+		AfterUpdate: func(user User) error { return nil },
+	})
+	require.ErrorIs(t, err, ErrEmailTaken)
+
+	var staged User
+	updated, err := store.ChangeEmailTx(context.Background(), ChangeEmailTxParams{
+		UserID:   user.UserID,
+		NewEmail: newEmail,
+		AfterUpdate: func(user User) error {
+			staged = user
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Email, updated.Email)
+	require.Equal(t, newEmail, updated.PendingEmail.String)
+	require.Equal(t, updated, staged)
+
+	// a code sent to the pending email swaps it in
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		UserID:     user.UserID,
+		Email:      newEmail,
+		SecretCode: util.RandomStr(32),
+	})
+	require.NoError(t, err)
+	result, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+	require.Equal(t, newEmail, result.User.Email)
+	require.False(t, result.User.PendingEmail.Valid)
+	require.True(t, result.User.IsEmailVerified)
+}
+
+func TestSearchUsersLiteralUnderscore(t *testing.T) {
+	prefix := util.RandomStr(10)
+	newUser := func(fullName string) User {
+		password, err := util.HashPassword(util.RandomStr(12))
+		require.NoError(t, err)
+		user, err := testQueries.CreateUser(context.Background(), CreateUserParams{
+			HashedPassword: password,
+			FullName:       fullName,
+			Email:          util.RandomEmail(),
+			Role:           "user",
+		})
+		require.NoError(t, err)
+		return user
+	}
+	underscored := newUser(prefix + "_a")
+	newUser(prefix + "xa")
+
+	// _ and % in the query are matched as themselves, not as wildcards
+	users, err := testQueries.SearchUsers(context.Background(), SearchUsersParams{
+		Query:      prefix + "_",
+		LimitCount: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, underscored.UserID, users[0].UserID)
+
+	users, err = testQueries.SearchUsers(context.Background(), SearchUsersParams{
+		Query:      prefix + "%",
+		LimitCount: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, users)
+}
@@ -1,6 +1,12 @@
 package db
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var ErrEmailTaken = errors.New("email is already in use")
 
 type CreateUserTxParams struct {
 	CreateUserParams CreateUserParams
@@ -33,3 +39,46 @@ func (store *StoreSQL) CreateUserTx(ctx context.Context, arg CreateUserTxParams)
 
 	return result, err
 }
+
+type ChangeEmailTxParams struct {
+	UserID   int64  `json:"user_id"`
+	NewEmail string `json:"new_email"`
+	// AfterUpdate is called with the staged user, Email still holds the
+	// current address and PendingEmail the new one.
+	AfterUpdate func(user User) error
+}
+
+// ChangeEmailTx stages NewEmail as the pending email of the user, the email
+// itself only changes once the new address is verified. AfterUpdate isn't
+// called when NewEmail is the current email of the user.
+func (store *StoreSQL) ChangeEmailTx(ctx context.Context, arg ChangeEmailTxParams) (User, error) {
+	var result User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		owner, err := q.GetUserByEmail(ctx, arg.NewEmail)
+		if err == nil {
+			if owner.UserID != arg.UserID {
+				return ErrEmailTaken
+			}
+			// changing back to the current email cancels the pending one
+			result, err = q.UpdateUserPendingEmail(ctx, UpdateUserPendingEmailParams{
+				UserID: arg.UserID,
+			})
+			return err
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		result, err = q.UpdateUserPendingEmail(ctx, UpdateUserPendingEmailParams{
+			PendingEmail: sql.NullString{String: arg.NewEmail, Valid: true},
+			UserID:       arg.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		return arg.AfterUpdate(result)
+	})
+
+	return result, err
+}
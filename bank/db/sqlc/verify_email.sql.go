@@ -38,3 +38,68 @@ func (q *Queries) CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailPa
 	)
 	return i, err
 }
+
+const getLatestVerifyEmail = `-- name: GetLatestVerifyEmail :one
+SELECT id, user_id, email, secret_code, is_used, created_at, expired_at FROM verify_emails
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestVerifyEmail(ctx context.Context, userID int64) (VerifyEmail, error) {
+	row := q.db.QueryRowContext(ctx, getLatestVerifyEmail, userID)
+	var i VerifyEmail
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const getVerifyEmailForUpdate = `-- name: GetVerifyEmailForUpdate :one
+SELECT id, user_id, email, secret_code, is_used, created_at, expired_at FROM verify_emails
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetVerifyEmailForUpdate(ctx context.Context, id int64) (VerifyEmail, error) {
+	row := q.db.QueryRowContext(ctx, getVerifyEmailForUpdate, id)
+	var i VerifyEmail
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
+
+const markVerifyEmailUsed = `-- name: MarkVerifyEmailUsed :one
+UPDATE verify_emails
+  set is_used = true
+WHERE id = $1
+RETURNING id, user_id, email, secret_code, is_used, created_at, expired_at
+`
+
+func (q *Queries) MarkVerifyEmailUsed(ctx context.Context, id int64) (VerifyEmail, error) {
+	row := q.db.QueryRowContext(ctx, markVerifyEmailUsed, id)
+	var i VerifyEmail
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiredAt,
+	)
+	return i, err
+}
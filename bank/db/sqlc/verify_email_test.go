@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createTestVerifyEmail(t *testing.T, user User) VerifyEmail {
+	arg := CreateVerifyEmailParams{
+		UserID:     user.UserID,
+		Email:      user.Email,
+		SecretCode: util.RandomStr(32),
+	}
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.SecretCode, verifyEmail.SecretCode)
+	require.False(t, verifyEmail.IsUsed)
+	return verifyEmail
+}
+
+func TestVerifyEmailTx(t *testing.T) {
+	store := NewStore(testDb)
+	user := createTestUser(t)
+	verifyEmail := createTestVerifyEmail(t, user)
+
+	_, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: util.RandomStr(32),
+	})
+	require.ErrorIs(t, err, ErrVerifyEmailMismatch)
+
+	result, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+	require.True(t, result.VerifyEmail.IsUsed)
+	require.True(t, result.User.IsEmailVerified)
+
+	_, err = store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.ErrorIs(t, err, ErrVerifyEmailUsed)
+
+	latest, err := testQueries.GetLatestVerifyEmail(context.Background(), user.UserID)
+	require.NoError(t, err)
+	require.Equal(t, verifyEmail.ID, latest.ID)
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrVerifyEmailUsed     = errors.New("verification code was already used")
+	ErrVerifyEmailExpired  = errors.New("verification code has expired")
+	ErrVerifyEmailMismatch = errors.New("verification code is invalid")
+	ErrVerifyEmailOutdated = errors.New("verification code was sent to a different email address")
+)
+
+type VerifyEmailTxParams struct {
+	EmailID    int64  `json:"email_id"`
+	SecretCode string `json:"secret_code"`
+}
+type VerifyEmailTxResult struct {
+	User        User        `json:"user"`
+	VerifyEmail VerifyEmail `json:"verify_email"`
+}
+
+// VerifyEmailTx consumes a verification code and marks the email address it
+// was sent to as verified. A code sent to the pending email of the user swaps
+// it in as their email.
+func (store *StoreSQL) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
+	var result VerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		verifyEmail, err := q.GetVerifyEmailForUpdate(ctx, arg.EmailID)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare([]byte(verifyEmail.SecretCode), []byte(arg.SecretCode)) != 1 {
+			return ErrVerifyEmailMismatch
+		}
+		if verifyEmail.IsUsed {
+			return ErrVerifyEmailUsed
+		}
+		if time.Now().After(verifyEmail.ExpiredAt) {
+			return ErrVerifyEmailExpired
+		}
+
+		user, err := q.GetUser(ctx, verifyEmail.UserID)
+		if err != nil {
+			return err
+		}
+		isPendingEmail := user.PendingEmail.Valid && user.PendingEmail.String == verifyEmail.Email
+		if !isPendingEmail && user.Email != verifyEmail.Email {
+			return ErrVerifyEmailOutdated
+		}
+
+		result.VerifyEmail, err = q.MarkVerifyEmailUsed(ctx, verifyEmail.ID)
+		if err != nil {
+			return err
+		}
+		if isPendingEmail {
+			result.User, err = q.ApplyPendingEmail(ctx, user.UserID)
+			return err
+		}
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			IsEmailVerified: sql.NullBool{Bool: true, Valid: true},
+			UserID:          user.UserID,
+		})
+		return err
+	})
+
+	return result, err
+}
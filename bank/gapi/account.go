@@ -0,0 +1,222 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultAccountsPage  int32 = 1
+	defaultAccountsLimit int32 = 5
+)
+
+func validateCreateAccountRequest(req *pb.CreateAccountReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	return violations
+}
+
+func validateGetAccountRequest(req *pb.GetAccountReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	return violations
+}
+
+func validateListAccountsRequest(req *pb.ListAccountsReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.Page != nil {
+		if err := val.ValidatePage(req.GetPage()); err != nil {
+			violations = append(violations, fieldViolation("page", err))
+		}
+	}
+	if req.Limit != nil {
+		if err := val.ValidateLimit(req.GetLimit()); err != nil {
+			violations = append(violations, fieldViolation("limit", err))
+		}
+	}
+	return violations
+}
+
+func validateCloseAccountRequest(req *pb.CloseAccountReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.SweepToAccountId != nil {
+		if err := val.ValidateID(req.GetSweepToAccountId()); err != nil {
+			violations = append(violations, fieldViolation("sweep_to_account_id", err))
+		}
+		if req.GetSweepToAccountId() == req.GetId() {
+			violations = append(violations, fieldViolation("sweep_to_account_id", fmt.Errorf("must be different from id")))
+		}
+	}
+	return violations
+}
+
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountReq) (*pb.CreateAccountRes, error) {
+	violations := validateCreateAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.Store.CreateAccount(ctx, db.CreateAccountParams{
+		Owner:    int64(payload.UserID),
+		Balance:  0,
+		Currency: req.GetCurrency(),
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "user already has a %s account", req.GetCurrency())
+		}
+		return nil, status.Errorf(codes.Internal, "create account failed %v", err)
+	}
+
+	res := &pb.CreateAccountRes{
+		Status: "Create account successfully",
+		Data:   ConvertAccount(account),
+	}
+	return res, nil
+}
+
+func (server *Server) GetAccount(ctx context.Context, req *pb.GetAccountReq) (*pb.GetAccountRes, error) {
+	violations := validateGetAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.Store.GetAccount(ctx, req.GetId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error when getting account %v", err)
+	}
+	if account.Owner != int64(payload.UserID) {
+		return nil, status.Errorf(codes.PermissionDenied, "account doesn't belong to that user")
+	}
+
+	res := &pb.GetAccountRes{
+		Status: "Get account successfully",
+		Data:   ConvertAccount(account),
+	}
+	return res, nil
+}
+
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsReq) (*pb.ListAccountsRes, error) {
+	violations := validateListAccountsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, limit := defaultAccountsPage, defaultAccountsLimit
+	if req.Page != nil {
+		page = req.GetPage()
+	}
+	if req.Limit != nil {
+		limit = req.GetLimit()
+	}
+	accounts, err := server.Store.ListAccounts(ctx, db.ListAccountsParams{
+		Owner:  int64(payload.UserID),
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when listing accounts %v", err)
+	}
+
+	data := make([]*pb.Account, 0, len(accounts))
+	for _, account := range accounts {
+		data = append(data, ConvertAccount(account))
+	}
+	res := &pb.ListAccountsRes{
+		Status: "Get accounts list successfully",
+		Data:   data,
+	}
+	return res, nil
+}
+
+// CloseAccount closes an account of the user. An account holding money
+// needs sweep_to_account_id, its balance is moved there first, converted
+// when the currencies differ.
+func (server *Server) CloseAccount(ctx context.Context, req *pb.CloseAccountReq) (*pb.CloseAccountRes, error) {
+	violations := validateCloseAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.Store.GetAccount(ctx, req.GetId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error when getting account %v", err)
+	}
+	if account.Owner != int64(payload.UserID) {
+		return nil, status.Errorf(codes.PermissionDenied, "account doesn't belong to that user")
+	}
+
+	arg := db.CloseAccountTxParams{AccountID: account.ID}
+	if req.SweepToAccountId != nil && account.Balance > 0 {
+		sweepAccount, err := server.getTransferAccount(ctx, req.GetSweepToAccountId(), "sweep_to_account_id")
+		if err != nil {
+			return nil, err
+		}
+		if sweepAccount.Owner != account.Owner {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+				fieldViolation("sweep_to_account_id", fmt.Errorf("must be another account of the same user")),
+			})
+		}
+		arg.Sweep = &db.TransferTxParams{
+			FromAccountId: account.ID,
+			ToAccountId:   sweepAccount.ID,
+			Amount:        account.Balance,
+		}
+		if sweepAccount.Currency != account.Currency {
+			if err := server.convertTransfer(ctx, arg.Sweep, account.Currency, sweepAccount.Currency); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result, err := server.Store.CloseAccountTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrAccountNotEmpty) || errors.Is(err, db.ErrAccountFrozen) || errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "close account failed %v", err)
+	}
+
+	res := &pb.CloseAccountRes{
+		Status: "Close account successfully",
+		Data:   ConvertAccount(result.Account),
+	}
+	if result.Sweep != nil {
+		res.Sweep = ConvertTransferTxResult(*result.Sweep)
+	}
+	return res, nil
+}
@@ -0,0 +1,470 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/val"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultAdminPage  int32 = 1
+	defaultAdminLimit int32 = 20
+)
+
+// actions and targets written to the audit log
+const (
+	auditActionSearchUsers      = "users.search"
+	auditActionListUserAccounts = "users.list_accounts"
+	auditActionListUserSessions = "users.list_sessions"
+	auditActionUpdateUserRole   = "users.update_role"
+	auditActionLockUser         = "users.lock"
+	auditActionUnlockUser       = "users.unlock"
+	auditActionFreezeAccount    = "accounts.freeze"
+	auditActionUnfreezeAccount  = "accounts.unfreeze"
+	auditActionBlockSession     = "sessions.block"
+	auditActionListAuditLogs    = "audit_logs.list"
+
+	auditTargetUser    = "user"
+	auditTargetAccount = "account"
+	auditTargetSession = "session"
+)
+
+// AdminServer serves the AdminService next to SimpleBank and shares its
+// store and config. The interceptors only let roles granted users:admin
+// through.
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	*Server
+}
+
+func NewAdminServer(server *Server) *AdminServer {
+	return &AdminServer{Server: server}
+}
+
+// audit runs apply and records it as done by the calling admin in one
+// transaction, reads are recorded too so the log shows who looked at what.
+func (server *AdminServer) audit(ctx context.Context, action, targetType, targetID string, apply func(q *db.Queries) (any, error)) error {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = server.Store.AdminActionTx(ctx, db.AdminActionTxParams{
+		ActorID:    int64(payload.UserID),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Apply:      apply,
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "%s failed %v", action, err)
+	}
+	return nil
+}
+
+// refuseSelf keeps an admin from locking themselves or taking away their
+// own role, which could leave no one able to undo it.
+func refuseSelf(ctx context.Context, userID int64) error {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return err
+	}
+	if int64(payload.UserID) == userID {
+		return status.Errorf(codes.FailedPrecondition, "admins can't change their own account")
+	}
+	return nil
+}
+
+func notFoundOr(err error, what string) error {
+	if err == sql.ErrNoRows {
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	}
+	return err
+}
+
+func validatePagination(page, limit *int32) (violations []*errdetails.BadRequest_FieldViolation) {
+	if page != nil {
+		if err := val.ValidatePage(*page); err != nil {
+			violations = append(violations, fieldViolation("page", err))
+		}
+	}
+	if limit != nil {
+		if err := val.ValidateLimit(*limit); err != nil {
+			violations = append(violations, fieldViolation("limit", err))
+		}
+	}
+	return violations
+}
+
+func paginate(page, limit *int32) (int32, int32) {
+	pageValue, limitValue := defaultAdminPage, defaultAdminLimit
+	if page != nil {
+		pageValue = *page
+	}
+	if limit != nil {
+		limitValue = *limit
+	}
+	return limitValue, (pageValue - 1) * limitValue
+}
+
+func validateReason(reason string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(reason, 0, 500); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+	return violations
+}
+
+type reasonDetails struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+func (server *AdminServer) SearchUsers(ctx context.Context, req *pb.SearchUsersReq) (*pb.SearchUsersRes, error) {
+	violations := validatePagination(req.Page, req.Limit)
+	if err := val.ValidateString(req.GetQuery(), 1, 200); err != nil {
+		violations = append(violations, fieldViolation("query", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	limit, offset := paginate(req.Page, req.Limit)
+	var users []db.User
+	err := server.audit(ctx, auditActionSearchUsers, auditTargetUser, "", func(q *db.Queries) (any, error) {
+		var err error
+		users, err = q.SearchUsers(ctx, db.SearchUsersParams{
+			Query:       req.GetQuery(),
+			LimitCount:  limit,
+			OffsetCount: offset,
+		})
+		return map[string]any{"query": req.GetQuery(), "results": len(users)}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*pb.User, 0, len(users))
+	for _, user := range users {
+		data = append(data, ConvertUser(user))
+	}
+	res := &pb.SearchUsersRes{
+		Status: "Search users successfully",
+		Data:   data,
+	}
+	return res, nil
+}
+
+func (server *AdminServer) ListUserAccounts(ctx context.Context, req *pb.ListUserAccountsReq) (*pb.ListUserAccountsRes, error) {
+	violations := validatePagination(req.Page, req.Limit)
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	limit, offset := paginate(req.Page, req.Limit)
+	var accounts []db.Account
+	err := server.audit(ctx, auditActionListUserAccounts, auditTargetUser, strconv.FormatInt(req.GetUserId(), 10), func(q *db.Queries) (any, error) {
+		if _, err := q.GetUser(ctx, req.GetUserId()); err != nil {
+			return nil, notFoundOr(err, "user")
+		}
+		var err error
+		accounts, err = q.ListAccounts(ctx, db.ListAccountsParams{
+			Owner:  req.GetUserId(),
+			Limit:  limit,
+			Offset: offset,
+		})
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*pb.Account, 0, len(accounts))
+	for _, account := range accounts {
+		data = append(data, ConvertAccount(account))
+	}
+	res := &pb.ListUserAccountsRes{
+		Status: "List user accounts successfully",
+		Data:   data,
+	}
+	return res, nil
+}
+
+func (server *AdminServer) ListUserSessions(ctx context.Context, req *pb.ListUserSessionsReq) (*pb.ListUserSessionsRes, error) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("user_id", err)})
+	}
+
+	var sessions []db.Session
+	err := server.audit(ctx, auditActionListUserSessions, auditTargetUser, strconv.FormatInt(req.GetUserId(), 10), func(q *db.Queries) (any, error) {
+		if _, err := q.GetUser(ctx, req.GetUserId()); err != nil {
+			return nil, notFoundOr(err, "user")
+		}
+		var err error
+		sessions, err = q.ListActiveSessions(ctx, req.GetUserId())
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*pb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, ConvertSession(session, uuid.Nil))
+	}
+	res := &pb.ListUserSessionsRes{
+		Status: "List user sessions successfully",
+		Data:   data,
+	}
+	return res, nil
+}
+
+// UpdateUserRole also blocks the sessions of the user, their tokens carry
+// the role they were issued with.
+func (server *AdminServer) UpdateUserRole(ctx context.Context, req *pb.UpdateUserRoleReq) (*pb.UpdateUserRoleRes, error) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+	role := db.UserRole(req.GetRole())
+	switch role {
+	case db.UserRoleGuest, db.UserRoleUser, db.UserRoleAdmin:
+	default:
+		violations = append(violations, fieldViolation("role", fmt.Errorf("must be %s, %s or %s", db.UserRoleGuest, db.UserRoleUser, db.UserRoleAdmin)))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	if err := refuseSelf(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	var user db.User
+	err := server.audit(ctx, auditActionUpdateUserRole, auditTargetUser, strconv.FormatInt(req.GetUserId(), 10), func(q *db.Queries) (any, error) {
+		previous, err := q.GetUser(ctx, req.GetUserId())
+		if err != nil {
+			return nil, notFoundOr(err, "user")
+		}
+		user, err = q.UpdateUserRole(ctx, db.UpdateUserRoleParams{Role: role, UserID: req.GetUserId()})
+		if err != nil {
+			return nil, err
+		}
+		if err := q.BlockUserSessions(ctx, user.UserID); err != nil {
+			return nil, err
+		}
+		return map[string]any{"previous_role": previous.Role, "role": user.Role}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.UpdateUserRoleRes{
+		Status: "Update user role successfully",
+		Data:   ConvertUser(user),
+	}
+	return res, nil
+}
+
+// LockUser keeps the user from logging in and ends their sessions, their
+// API keys stop working while they're locked.
+func (server *AdminServer) LockUser(ctx context.Context, req *pb.LockUserReq) (*pb.LockUserRes, error) {
+	violations := validateReason(req.GetReason())
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	if err := refuseSelf(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	var user db.User
+	err := server.audit(ctx, auditActionLockUser, auditTargetUser, strconv.FormatInt(req.GetUserId(), 10), func(q *db.Queries) (any, error) {
+		var err error
+		user, err = q.SetUserLocked(ctx, db.SetUserLockedParams{IsLocked: true, UserID: req.GetUserId()})
+		if err != nil {
+			return nil, notFoundOr(err, "user")
+		}
+		if err := q.BlockUserSessions(ctx, user.UserID); err != nil {
+			return nil, err
+		}
+		return reasonDetails{Reason: req.GetReason()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.LockUserRes{
+		Status: "Lock user successfully",
+		Data:   ConvertUser(user),
+	}
+	return res, nil
+}
+
+func (server *AdminServer) UnlockUser(ctx context.Context, req *pb.UnlockUserReq) (*pb.UnlockUserRes, error) {
+	violations := validateReason(req.GetReason())
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	var user db.User
+	err := server.audit(ctx, auditActionUnlockUser, auditTargetUser, strconv.FormatInt(req.GetUserId(), 10), func(q *db.Queries) (any, error) {
+		var err error
+		user, err = q.SetUserLocked(ctx, db.SetUserLockedParams{IsLocked: false, UserID: req.GetUserId()})
+		if err != nil {
+			return nil, notFoundOr(err, "user")
+		}
+		return reasonDetails{Reason: req.GetReason()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.UnlockUserRes{
+		Status: "Unlock user successfully",
+		Data:   ConvertUser(user),
+	}
+	return res, nil
+}
+
+// FreezeAccount stops money moving in or out of the account, TransferTx
+// refuses it until it's unfrozen.
+func (server *AdminServer) FreezeAccount(ctx context.Context, req *pb.FreezeAccountReq) (*pb.FreezeAccountRes, error) {
+	account, err := server.setAccountStatus(ctx, auditActionFreezeAccount, req.GetAccountId(), req.GetReason(), db.AccountStatusActive, db.AccountStatusFrozen)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.FreezeAccountRes{
+		Status: "Freeze account successfully",
+		Data:   ConvertAccount(account),
+	}
+	return res, nil
+}
+
+func (server *AdminServer) UnfreezeAccount(ctx context.Context, req *pb.UnfreezeAccountReq) (*pb.UnfreezeAccountRes, error) {
+	account, err := server.setAccountStatus(ctx, auditActionUnfreezeAccount, req.GetAccountId(), req.GetReason(), db.AccountStatusFrozen, db.AccountStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.UnfreezeAccountRes{
+		Status: "Unfreeze account successfully",
+		Data:   ConvertAccount(account),
+	}
+	return res, nil
+}
+
+// setAccountStatus moves the account from one status to another, closed
+// accounts are never reopened this way.
+func (server *AdminServer) setAccountStatus(ctx context.Context, action string, accountID int64, reason string, from db.AccountStatus, to db.AccountStatus) (db.Account, error) {
+	violations := validateReason(reason)
+	if err := val.ValidateID(accountID); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if violations != nil {
+		return db.Account{}, invalidArgumentError(violations)
+	}
+
+	var account db.Account
+	err := server.audit(ctx, action, auditTargetAccount, strconv.FormatInt(accountID, 10), func(q *db.Queries) (any, error) {
+		current, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return nil, notFoundOr(err, "account")
+		}
+		if current.Status != from {
+			return nil, status.Errorf(codes.FailedPrecondition, "account is %s", current.Status)
+		}
+		account, err = q.UpdateAccountStatus(ctx, db.UpdateAccountStatusParams{Status: to, ID: accountID})
+		if err != nil {
+			return nil, err
+		}
+		return reasonDetails{Reason: reason}, nil
+	})
+	return account, err
+}
+
+// BlockSession blocks the whole family of the session like RevokeSession
+// does for the user's own sessions.
+func (server *AdminServer) BlockSession(ctx context.Context, req *pb.BlockSessionReq) (*pb.BlockSessionRes, error) {
+	violations := validateReason(req.GetReason())
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		violations = append(violations, fieldViolation("session_id", fmt.Errorf("must be a valid session id")))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	err = server.audit(ctx, auditActionBlockSession, auditTargetSession, sessionID.String(), func(q *db.Queries) (any, error) {
+		row, err := q.GetSession(ctx, sessionID)
+		if err != nil {
+			return nil, notFoundOr(err, "session")
+		}
+		if err := q.BlockSessionFamily(ctx, row.Session.FamilyID); err != nil {
+			return nil, err
+		}
+		return map[string]any{"user_id": row.Session.UserID, "reason": req.GetReason()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.BlockSessionRes{
+		Status: "Block session successfully",
+	}
+	return res, nil
+}
+
+func (server *AdminServer) ListAuditLogs(ctx context.Context, req *pb.ListAuditLogsReq) (*pb.ListAuditLogsRes, error) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	switch req.GetTargetType() {
+	case auditTargetUser, auditTargetAccount, auditTargetSession:
+	default:
+		violations = append(violations, fieldViolation("target_type", fmt.Errorf("must be %s, %s or %s", auditTargetUser, auditTargetAccount, auditTargetSession)))
+	}
+	if err := val.ValidateString(req.GetTargetId(), 1, 100); err != nil {
+		violations = append(violations, fieldViolation("target_id", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	var auditLogs []db.AuditLog
+	// reading the log of a target is itself logged against that target
+	err := server.audit(ctx, auditActionListAuditLogs, req.GetTargetType(), req.GetTargetId(), func(q *db.Queries) (any, error) {
+		var err error
+		auditLogs, err = q.ListAuditLogsByTarget(ctx, db.ListAuditLogsByTargetParams{
+			TargetType: req.GetTargetType(),
+			TargetID:   req.GetTargetId(),
+		})
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*pb.AuditLog, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		data = append(data, ConvertAuditLog(auditLog))
+	}
+	res := &pb.ListAuditLogsRes{
+		Status: "List audit logs successfully",
+		Data:   data,
+	}
+	return res, nil
+}
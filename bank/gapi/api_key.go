@@ -0,0 +1,121 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/apikey"
+	"main/pkg/authz"
+	"main/pkg/val"
+	"slices"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func validateCreateApiKeyRequest(req *pb.CreateApiKeyReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		violations = append(violations, fieldViolation("user_id", err))
+	}
+	if err := val.ValidateString(req.GetName(), 1, 100); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+	if len(req.GetPermissions()) == 0 {
+		violations = append(violations, fieldViolation("permissions", fmt.Errorf("must contain at least one permission")))
+	}
+	for _, permission := range req.GetPermissions() {
+		if !authz.IsValidPermission(permission) {
+			violations = append(violations, fieldViolation("permissions", fmt.Errorf("%q is not a valid permission", permission)))
+		}
+	}
+	if req.ExpiredAt == nil || !req.GetExpiredAt().AsTime().After(time.Now()) {
+		violations = append(violations, fieldViolation("expired_at", fmt.Errorf("must be in the future")))
+	}
+	return violations
+}
+
+// CreateApiKey mints a key acting as the given user for machine clients, the
+// key is only ever returned here.
+func (server *Server) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyReq) (*pb.CreateApiKeyRes, error) {
+	violations := validateCreateApiKeyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if _, err := server.Store.GetUser(ctx, req.GetUserId()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error when getting user %v", err)
+	}
+
+	key, err := apikey.NewKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when generating api key %v", err)
+	}
+	permissions := slices.Clone(req.GetPermissions())
+	slices.Sort(permissions)
+	apiKey, err := server.Store.CreateApiKey(ctx, db.CreateApiKeyParams{
+		UserID:      req.GetUserId(),
+		Name:        req.GetName(),
+		KeyPrefix:   key.Prefix,
+		KeyHash:     key.Hash,
+		Permissions: slices.Compact(permissions),
+		ExpiredAt:   req.GetExpiredAt().AsTime(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create api key failed %v", err)
+	}
+
+	res := &pb.CreateApiKeyRes{
+		Status: "Create api key successfully",
+		Data:   ConvertApiKey(apiKey),
+		Key:    key.Key,
+	}
+	return res, nil
+}
+
+func (server *Server) ListApiKeys(ctx context.Context, req *pb.ListApiKeysReq) (*pb.ListApiKeysRes, error) {
+	if err := val.ValidateID(req.GetUserId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("user_id", err)})
+	}
+
+	apiKeys, err := server.Store.ListApiKeys(ctx, req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when listing api keys %v", err)
+	}
+
+	data := make([]*pb.ApiKey, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		data = append(data, ConvertApiKey(apiKey))
+	}
+	res := &pb.ListApiKeysRes{
+		Status: "List api keys successfully",
+		Data:   data,
+	}
+	return res, nil
+}
+
+func (server *Server) RevokeApiKey(ctx context.Context, req *pb.RevokeApiKeyReq) (*pb.RevokeApiKeyRes, error) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	apiKey, err := server.Store.RevokeApiKey(ctx, req.GetId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "api key not found or already revoked")
+		}
+		return nil, status.Errorf(codes.Internal, "revoke api key failed %v", err)
+	}
+
+	res := &pb.RevokeApiKeyRes{
+		Status: "Revoke api key successfully",
+		Data:   ConvertApiKey(apiKey),
+	}
+	return res, nil
+}
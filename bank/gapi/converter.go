@@ -4,6 +4,7 @@ import (
 	db "main/db/sqlc"
 	"main/pb"
 
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -15,5 +16,138 @@ func ConvertUser(user db.User) *pb.User {
 		Role:              string(user.Role),
 		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
 		CreatedAt:         timestamppb.New(user.CreatedAt),
+		PendingEmail:      user.PendingEmail.String,
+		IsLocked:          user.IsLocked,
+	}
+}
+
+func ConvertAccount(account db.Account) *pb.Account {
+	res := &pb.Account{
+		Id:             account.ID,
+		Owner:          account.Owner,
+		Balance:        account.Balance,
+		Currency:       account.Currency,
+		CreatedAt:      timestamppb.New(account.CreatedAt),
+		OverdraftLimit: account.OverdraftLimit,
+		Status:         string(account.Status),
+	}
+	if account.ClosedAt.Valid {
+		res.ClosedAt = timestamppb.New(account.ClosedAt.Time)
+	}
+	return res
+}
+
+func ConvertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:              transfer.ID,
+		FromAccountId:   transfer.FromAccountID,
+		ToAccountId:     transfer.ToAccountID,
+		Amount:          transfer.Amount,
+		CreatedAt:       timestamppb.New(transfer.CreatedAt),
+		ExchangeRate:    transfer.ExchangeRate,
+		ConvertedAmount: transfer.ConvertedAmount,
+	}
+}
+
+func ConvertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:        entry.ID,
+		AccountId: entry.AccountID,
+		Amount:    entry.Amount,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+}
+
+func ConvertAccountEntry(entry db.ListAccountEntriesRow) *pb.AccountEntry {
+	return &pb.AccountEntry{
+		Id:             entry.ID,
+		AccountId:      entry.AccountID,
+		Amount:         entry.Amount,
+		RunningBalance: entry.BalanceAfter,
+		CreatedAt:      timestamppb.New(entry.CreatedAt),
+	}
+}
+
+func ConvertTransferTxResult(result db.TransferTxResult) *pb.TransferTxResult {
+	return &pb.TransferTxResult{
+		Transfer:    ConvertTransfer(result.Transfer),
+		FromAccount: ConvertAccount(result.FromAccount),
+		ToAccount:   ConvertAccount(result.ToAccount),
+		FromEntry:   ConvertEntry(result.FromEntry),
+		ToEntry:     ConvertEntry(result.ToEntry),
+	}
+}
+
+func ConvertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	res := &pb.ScheduledTransfer{
+		Id:                  scheduledTransfer.ID,
+		Owner:               scheduledTransfer.Owner,
+		FromAccountId:       scheduledTransfer.FromAccountID,
+		ToAccountId:         scheduledTransfer.ToAccountID,
+		Amount:              scheduledTransfer.Amount,
+		CronExpression:      scheduledTransfer.CronExpression.String,
+		IntervalSeconds:     scheduledTransfer.IntervalSeconds.Int64,
+		NextRunAt:           timestamppb.New(scheduledTransfer.NextRunAt),
+		Status:              string(scheduledTransfer.Status),
+		ConsecutiveFailures: scheduledTransfer.ConsecutiveFailures,
+		CreatedAt:           timestamppb.New(scheduledTransfer.CreatedAt),
+		UpdatedAt:           timestamppb.New(scheduledTransfer.UpdatedAt),
+	}
+	if scheduledTransfer.EndAt.Valid {
+		res.EndAt = timestamppb.New(scheduledTransfer.EndAt.Time)
+	}
+	return res
+}
+
+func ConvertScheduledTransferAttempt(attempt db.ScheduledTransferAttempt) *pb.ScheduledTransferAttempt {
+	return &pb.ScheduledTransferAttempt{
+		Id:                  attempt.ID,
+		ScheduledTransferId: attempt.ScheduledTransferID,
+		ScheduledFor:        timestamppb.New(attempt.ScheduledFor),
+		Status:              string(attempt.Status),
+		TransferId:          attempt.TransferID.Int64,
+		FailureReason:       attempt.FailureReason,
+		Retries:             attempt.Retries,
+		CreatedAt:           timestamppb.New(attempt.CreatedAt),
+		UpdatedAt:           timestamppb.New(attempt.UpdatedAt),
+	}
+}
+
+func ConvertSession(session db.Session, currentSessionID uuid.UUID) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		CreatedAt: timestamppb.New(session.CreatedAt),
+		ExpiredAt: timestamppb.New(session.ExpiredAt),
+		IsCurrent: session.ID == currentSessionID,
+	}
+}
+
+func ConvertApiKey(apiKey db.ApiKey) *pb.ApiKey {
+	res := &pb.ApiKey{
+		Id:          apiKey.ID,
+		UserId:      apiKey.UserID,
+		Name:        apiKey.Name,
+		KeyPrefix:   apiKey.KeyPrefix,
+		Permissions: apiKey.Permissions,
+		ExpiredAt:   timestamppb.New(apiKey.ExpiredAt),
+		CreatedAt:   timestamppb.New(apiKey.CreatedAt),
+	}
+	if apiKey.RevokedAt.Valid {
+		res.RevokedAt = timestamppb.New(apiKey.RevokedAt.Time)
+	}
+	return res
+}
+
+func ConvertAuditLog(auditLog db.AuditLog) *pb.AuditLog {
+	return &pb.AuditLog{
+		Id:         auditLog.ID,
+		ActorId:    auditLog.ActorID,
+		Action:     auditLog.Action,
+		TargetType: auditLog.TargetType,
+		TargetId:   auditLog.TargetID,
+		Details:    string(auditLog.Details),
+		CreatedAt:  timestamppb.New(auditLog.CreatedAt),
 	}
 }
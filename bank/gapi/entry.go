@@ -0,0 +1,111 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/authz"
+	"main/pkg/val"
+	"main/token"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultEntriesPageSize int32 = 20
+
+func validateListAccountEntriesRequest(req *pb.ListAccountEntriesReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.PageSize != nil {
+		if err := val.ValidateLimit(req.GetPageSize()); err != nil {
+			violations = append(violations, fieldViolation("page_size", err))
+		}
+	}
+	if req.Cursor != nil {
+		if err := val.ValidateID(req.GetCursor()); err != nil {
+			violations = append(violations, fieldViolation("cursor", err))
+		}
+	}
+	if req.FromTime != nil && req.ToTime != nil && !req.GetFromTime().AsTime().Before(req.GetToTime().AsTime()) {
+		violations = append(violations, fieldViolation("to_time", fmt.Errorf("must be after from_time")))
+	}
+	return violations
+}
+
+func (server *Server) ListAccountEntries(ctx context.Context, req *pb.ListAccountEntriesReq) (*pb.ListAccountEntriesRes, error) {
+	violations := validateListAccountEntriesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.getReadableAccount(ctx, req.GetId(), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := defaultEntriesPageSize
+	if req.PageSize != nil {
+		pageSize = req.GetPageSize()
+	}
+	arg := db.ListAccountEntriesParams{
+		AccountID: account.ID,
+		Cursor:    sql.NullInt64{Int64: req.GetCursor(), Valid: req.Cursor != nil},
+		PageSize:  pageSize + 1,
+	}
+	if req.FromTime != nil {
+		arg.FromTime = sql.NullTime{Time: req.GetFromTime().AsTime(), Valid: true}
+	}
+	if req.ToTime != nil {
+		arg.ToTime = sql.NullTime{Time: req.GetToTime().AsTime(), Valid: true}
+	}
+	// one extra row is fetched to tell whether there is a next page
+	entries, err := server.Store.ListAccountEntries(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when listing entries %v", err)
+	}
+
+	res := &pb.ListAccountEntriesRes{
+		Status: "Get account entries successfully",
+	}
+	if len(entries) > int(pageSize) {
+		entries = entries[:pageSize]
+		nextCursor := entries[len(entries)-1].ID
+		res.NextCursor = &nextCursor
+	}
+	res.Data = make([]*pb.AccountEntry, 0, len(entries))
+	for _, entry := range entries {
+		res.Data = append(res.Data, ConvertAccountEntry(entry))
+	}
+	return res, nil
+}
+
+// getReadableAccount loads an account whose history the caller may read, that
+// is one they own, or any account for callers granted accounts:read_any.
+func (server *Server) getReadableAccount(ctx context.Context, accountID int64, payload *token.Payload) (db.Account, error) {
+	account, err := server.Store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account not found")
+		}
+		return account, status.Errorf(codes.Internal, "error when getting account %v", err)
+	}
+	if account.Owner != int64(payload.UserID) {
+		if err := server.Authorizer.Authorize(ctx, payload, authz.PermissionAccountsReadAny); err != nil {
+			if errors.Is(err, authz.ErrPermissionDenied) {
+				return account, status.Errorf(codes.PermissionDenied, "account doesn't belong to that user")
+			}
+			return account, status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+	return account, nil
+}
@@ -0,0 +1,46 @@
+package gapi
+
+import (
+	"context"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/pkg/authz"
+	"main/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetReadableAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := &Server{Store: store, Authorizer: authz.NewAuthorizer(store, authz.DefaultCacheTTL)}
+
+	account := db.Account{ID: 1, Owner: 2}
+	store.EXPECT().GetAccount(gomock.Any(), account.ID).AnyTimes().Return(account, nil)
+	store.EXPECT().ListRolePermissions(gomock.Any()).Return([]db.RolePermission{
+		{Role: db.UserRoleUser, Permission: authz.PermissionAccountsRead},
+		{Role: db.UserRoleAdmin, Permission: authz.PermissionAccountsRead},
+		{Role: db.UserRoleAdmin, Permission: authz.PermissionAccountsReadAny},
+	}, nil)
+
+	owner := &token.Payload{UserID: 2, Role: db.UserRoleUser}
+	_, err := server.getReadableAccount(context.Background(), account.ID, owner)
+	require.NoError(t, err)
+
+	admin := &token.Payload{UserID: 3, Role: db.UserRoleAdmin}
+	_, err = server.getReadableAccount(context.Background(), account.ID, admin)
+	require.NoError(t, err)
+
+	// an admin's key scoped to reading their own accounts stays scoped
+	adminKey := &token.Payload{UserID: 3, Role: db.UserRoleAdmin, APIKeyID: 1, Permissions: []string{authz.PermissionAccountsRead}}
+	_, err = server.getReadableAccount(context.Background(), account.ID, adminKey)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	user := &token.Payload{UserID: 4, Role: db.UserRoleUser}
+	_, err = server.getReadableAccount(context.Background(), account.ID, user)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
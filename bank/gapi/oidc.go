@@ -0,0 +1,144 @@
+package gapi
+
+import (
+	"database/sql"
+	db "main/db/sqlc"
+	"main/util"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/v1/auth/oidc"
+)
+
+// OIDCStartHandler sends the user to the identity provider. The state is
+// stored with the PKCE verifier and the nonce, and set in a cookie so the
+// callback only completes in the browser that started the login.
+func (server *Server) OIDCStartHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		authRequest, err := server.OIDCClient.NewAuthRequest(ctx)
+		if err != nil {
+			writeGatewayError(res, status.Errorf(codes.Unavailable, "%v", err))
+			return
+		}
+		_, err = server.Store.CreateOidcState(ctx, db.CreateOidcStateParams{
+			StateHash:    util.HashSecretToken(authRequest.State),
+			CodeVerifier: authRequest.CodeVerifier,
+			Nonce:        authRequest.Nonce,
+		})
+		if err != nil {
+			writeGatewayError(res, status.Errorf(codes.Internal, "error when creating oidc state %v", err))
+			return
+		}
+
+		http.SetCookie(res, &http.Cookie{
+			Name:     oidcStateCookie,
+			Value:    authRequest.State,
+			Path:     oidcStateCookiePath,
+			MaxAge:   int((10 * time.Minute).Seconds()),
+			HttpOnly: true,
+			Secure:   req.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(res, req, authRequest.URL, http.StatusFound)
+	})
+}
+
+// OIDCCallbackHandler completes the login the identity provider redirects
+// back with and answers like LoginUser.
+func (server *Server) OIDCCallbackHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		if providerErr := query.Get("error"); providerErr != "" {
+			writeGatewayError(res, status.Errorf(codes.Unauthenticated, "identity provider refused the login: %s", providerErr))
+			return
+		}
+		state := query.Get("state")
+		cookie, err := req.Cookie(oidcStateCookie)
+		if err != nil || state == "" || cookie.Value != state {
+			writeGatewayError(res, status.Errorf(codes.InvalidArgument, "login state doesn't match"))
+			return
+		}
+		http.SetCookie(res, &http.Cookie{Name: oidcStateCookie, Path: oidcStateCookiePath, MaxAge: -1})
+
+		// the session gets the user agent and ip like a LoginUser through the gateway
+		ctx := metadata.NewIncomingContext(req.Context(), metadata.Pairs(
+			util.GrpcGatewayAgent, req.UserAgent(),
+			util.XForwardFor, req.RemoteAddr,
+		))
+		oidcState, err := server.Store.UseOidcState(ctx, util.HashSecretToken(state))
+		if err != nil {
+			if err == sql.ErrNoRows {
+				writeGatewayError(res, status.Errorf(codes.InvalidArgument, "login expired or was already completed"))
+				return
+			}
+			writeGatewayError(res, status.Errorf(codes.Internal, "error when getting oidc state %v", err))
+			return
+		}
+
+		identity, err := server.OIDCClient.Exchange(ctx, query.Get("code"), oidcState.CodeVerifier, oidcState.Nonce)
+		if err != nil {
+			writeGatewayError(res, status.Errorf(codes.Unauthenticated, "%v", err))
+			return
+		}
+
+		secret, err := util.NewSecretToken(32)
+		if err != nil {
+			writeGatewayError(res, status.Errorf(codes.Internal, "%v", err))
+			return
+		}
+		hashedPassword, err := util.HashPassword(secret)
+		if err != nil {
+			writeGatewayError(res, status.Errorf(codes.Internal, "%v", err))
+			return
+		}
+		user, err := server.Store.LoginExternalIdentityTx(ctx, db.LoginExternalIdentityTxParams{
+			Issuer:         identity.Issuer,
+			Subject:        identity.Subject,
+			Email:          identity.Email,
+			FullName:       identity.FullName,
+			HashedPassword: hashedPassword,
+		})
+		if err != nil {
+			writeGatewayError(res, status.Errorf(codes.Internal, "error when linking identity %v", err))
+			return
+		}
+
+		loginRes, err := server.finishLogin(ctx, user)
+		if err != nil {
+			writeGatewayError(res, err)
+			return
+		}
+		writeGatewayResponse(res, http.StatusOK, loginRes)
+	})
+}
+
+var gatewayMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// writeGatewayResponse writes a message like the gateway mux would, for the
+// handlers that sit next to it.
+func writeGatewayResponse(res http.ResponseWriter, code int, message proto.Message) {
+	body, err := gatewayMarshaler.Marshal(message)
+	if err != nil {
+		http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(code)
+	res.Write(body)
+}
+
+func writeGatewayError(res http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeGatewayResponse(res, runtime.HTTPStatusFromCode(st.Code()), st.Proto())
+}
@@ -0,0 +1,106 @@
+package gapi
+
+import (
+	"database/sql"
+	"encoding/json"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/pkg/oidc"
+	"main/pkg/oidc/oidctest"
+	"main/pkg/throttle"
+	"main/token"
+	"main/util"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newOIDCTestServer(t *testing.T, store db.Store, provider *oidctest.Provider) *Server {
+	config := util.Config{
+		TokenDuration:        time.Minute,
+		RefreshTokenDuration: time.Hour,
+		OIDCIssuerURL:        provider.Issuer(),
+		OIDCClientID:         "simple-bank",
+		OIDCClientSecret:     util.RandomStr(16),
+		OIDCRedirectURL:      "http://localhost:8080/v1/auth/oidc/callback",
+	}
+	tokenMaker, err := token.NewPasetoMaker(util.RandomStr(32))
+	require.NoError(t, err)
+	return &Server{Config: config, Store: store, TokenMaker: tokenMaker, OIDCClient: oidc.NewClient(config), LoginThrottle: throttle.NewLoginThrottle(store, config)}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	provider := oidctest.NewProvider(t)
+	server := newOIDCTestServer(t, store, provider)
+
+	var oidcState db.CreateOidcStateParams
+	store.EXPECT().CreateOidcState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateOidcStateParams) (db.OidcState, error) {
+			oidcState = arg
+			return db.OidcState{StateHash: arg.StateHash, CodeVerifier: arg.CodeVerifier, Nonce: arg.Nonce}, nil
+		})
+
+	recorder := httptest.NewRecorder()
+	server.OIDCStartHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/start", nil))
+	require.Equal(t, http.StatusFound, recorder.Code)
+	authURL := recorder.Header().Get("Location")
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+	state := cookies[0].Value
+	require.Equal(t, util.HashSecretToken(state), oidcState.StateHash)
+
+	user := db.User{UserID: 1, Email: util.RandomEmail(), Role: db.UserRoleUser, IsEmailVerified: true}
+	code := provider.Authorize(authURL, jwt.MapClaims{
+		"sub":            "user-1",
+		"email":          user.Email,
+		"email_verified": true,
+		"name":           "Test User",
+	})
+
+	store.EXPECT().UseOidcState(gomock.Any(), oidcState.StateHash).
+		Return(db.OidcState{CodeVerifier: oidcState.CodeVerifier, Nonce: oidcState.Nonce}, nil)
+	store.EXPECT().LoginExternalIdentityTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.LoginExternalIdentityTxParams) (db.User, error) {
+			require.Equal(t, provider.Issuer(), arg.Issuer)
+			require.Equal(t, "user-1", arg.Subject)
+			require.Equal(t, user.Email, arg.Email)
+			return user, nil
+		})
+	store.EXPECT().GetTotpCredential(gomock.Any(), user.UserID).Return(db.TotpCredential{}, sql.ErrNoRows)
+	store.EXPECT().DeleteLoginAttempt(gomock.Any(), gomock.Any())
+	store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Return(db.Session{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/callback?"+url.Values{"state": {state}, "code": {code}}.Encode(), nil)
+	req.AddCookie(cookies[0])
+	recorder = httptest.NewRecorder()
+	server.OIDCCallbackHandler().ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	payload, err := server.TokenMaker.VerifyToken(res.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, int(user.UserID), payload.UserID)
+}
+
+func TestOIDCCallbackStateMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newOIDCTestServer(t, store, oidctest.NewProvider(t))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/callback?state=abc&code=def", nil)
+	req.AddCookie(&http.Cookie{Name: oidcStateCookie, Value: "other"})
+	recorder := httptest.NewRecorder()
+	server.OIDCCallbackHandler().ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,95 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/val"
+	"main/util"
+	"main/worker"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passwordResetInterval is the minimum time between two password reset
+// emails for the same address.
+const passwordResetInterval = time.Minute
+
+func validateRequestPasswordResetRequest(req *pb.RequestPasswordResetReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateEmail(req.GetEmail()); err != nil {
+		violations = append(violations, fieldViolation("email", err))
+	}
+	return violations
+}
+
+func validateResetPasswordRequest(req *pb.ResetPasswordReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetToken() == "" {
+		violations = append(violations, fieldViolation("token", fmt.Errorf("is required")))
+	}
+	if err := val.ValidatePassword(req.GetNewPassword()); err != nil {
+		violations = append(violations, fieldViolation("new_password", err))
+	}
+	return violations
+}
+
+// RequestPasswordReset answers the same whether the email is registered or
+// not, the worker drops unknown ones.
+func (server *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetReq) (*pb.RequestPasswordResetRes, error) {
+	violations := validateRequestPasswordResetRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Unique(passwordResetInterval),
+	}
+	err := server.TaskDistributor.DistributeTaskSendPasswordReset(ctx, &worker.PayloadSendPasswordReset{
+		Email: req.GetEmail(),
+	}, opts...)
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		return nil, status.Errorf(codes.Internal, "error when sending password reset %v", err)
+	}
+
+	res := &pb.RequestPasswordResetRes{
+		Status: "Password reset email will be sent if the email is registered",
+	}
+	return res, nil
+}
+
+func (server *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordReq) (*pb.ResetPasswordRes, error) {
+	violations := validateResetPasswordRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	hashedPassword, err := util.HashPassword(req.GetNewPassword())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "password hash failed")
+	}
+
+	_, err = server.Store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		TokenHash:      util.HashSecretToken(req.GetToken()),
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "password reset token not found")
+		}
+		if errors.Is(err, db.ErrPasswordResetUsed) || errors.Is(err, db.ErrPasswordResetExpired) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "reset password failed %v", err)
+	}
+
+	res := &pb.ResetPasswordRes{
+		Status: "Reset password successfully",
+	}
+	return res, nil
+}
@@ -0,0 +1,368 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/schedule"
+	"main/pkg/val"
+	"main/token"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultScheduledTransfersPage  int32 = 1
+	defaultScheduledTransfersLimit int32 = 5
+)
+
+func validateCreateScheduledTransferRequest(req *pb.CreateScheduledTransferReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from from_account_id")))
+	}
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if _, err := schedule.Parse(req.GetCronExpression(), req.GetIntervalSeconds()); err != nil {
+		violations = append(violations, fieldViolation("cron_expression", err))
+	}
+	if req.StartAt != nil && !req.GetStartAt().AsTime().After(time.Now()) {
+		violations = append(violations, fieldViolation("start_at", fmt.Errorf("must be in the future")))
+	}
+	return violations
+}
+
+func validateUpdateScheduledTransferRequest(req *pb.UpdateScheduledTransferReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+	if req.CronExpression != nil || req.IntervalSeconds != nil {
+		if _, err := schedule.Parse(req.GetCronExpression(), req.GetIntervalSeconds()); err != nil {
+			violations = append(violations, fieldViolation("cron_expression", err))
+		}
+	}
+	if req.Status != nil {
+		switch db.ScheduledTransferStatus(req.GetStatus()) {
+		case db.ScheduledTransferStatusActive, db.ScheduledTransferStatusPaused:
+		default:
+			violations = append(violations, fieldViolation("status", fmt.Errorf("must be %s or %s", db.ScheduledTransferStatusActive, db.ScheduledTransferStatusPaused)))
+		}
+	}
+	return violations
+}
+
+func (server *Server) CreateScheduledTransfer(ctx context.Context, req *pb.CreateScheduledTransferReq) (*pb.CreateScheduledTransferRes, error) {
+	violations := validateCreateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAccount, err := server.getTransferAccount(ctx, req.GetFromAccountId(), "from_account_id")
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Owner != int64(payload.UserID) {
+		return nil, status.Errorf(codes.PermissionDenied, "from account doesn't belong to that user")
+	}
+	toAccount, err := server.getTransferAccount(ctx, req.GetToAccountId(), "to_account_id")
+	if err != nil {
+		return nil, err
+	}
+	// the rate of a cross currency transfer is only known when it runs
+	if err := checkAccountCurrency(toAccount, fromAccount.Currency, "to_account_id"); err != nil {
+		return nil, err
+	}
+
+	runSchedule, _ := schedule.Parse(req.GetCronExpression(), req.GetIntervalSeconds())
+	now := time.Now()
+	nextRunAt := runSchedule.Next(now, now)
+	if req.StartAt != nil {
+		nextRunAt = req.GetStartAt().AsTime()
+	}
+	arg := db.CreateScheduledTransferParams{
+		Owner:           int64(payload.UserID),
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		Amount:          req.GetAmount(),
+		CronExpression:  sql.NullString{String: req.GetCronExpression(), Valid: req.GetCronExpression() != ""},
+		IntervalSeconds: sql.NullInt64{Int64: req.GetIntervalSeconds(), Valid: req.GetIntervalSeconds() != 0},
+		NextRunAt:       nextRunAt,
+	}
+	if req.EndAt != nil {
+		if !req.GetEndAt().AsTime().After(nextRunAt) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+				fieldViolation("end_at", fmt.Errorf("must be after the first run at %s", nextRunAt.UTC().Format(time.RFC3339))),
+			})
+		}
+		arg.EndAt = sql.NullTime{Time: req.GetEndAt().AsTime(), Valid: true}
+	}
+
+	scheduledTransfer, err := server.Store.CreateScheduledTransfer(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create scheduled transfer failed %v", err)
+	}
+
+	res := &pb.CreateScheduledTransferRes{
+		Status: "Create scheduled transfer successfully",
+		Data:   ConvertScheduledTransfer(scheduledTransfer),
+	}
+	return res, nil
+}
+
+func (server *Server) GetScheduledTransfer(ctx context.Context, req *pb.GetScheduledTransferReq) (*pb.GetScheduledTransferRes, error) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledTransfer, err := server.getOwnedScheduledTransfer(ctx, req.GetId(), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.GetScheduledTransferRes{
+		Status: "Get scheduled transfer successfully",
+		Data:   ConvertScheduledTransfer(scheduledTransfer),
+	}
+	return res, nil
+}
+
+func (server *Server) ListScheduledTransfers(ctx context.Context, req *pb.ListScheduledTransfersReq) (*pb.ListScheduledTransfersRes, error) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.Page != nil {
+		if err := val.ValidatePage(req.GetPage()); err != nil {
+			violations = append(violations, fieldViolation("page", err))
+		}
+	}
+	if req.Limit != nil {
+		if err := val.ValidateLimit(req.GetLimit()); err != nil {
+			violations = append(violations, fieldViolation("limit", err))
+		}
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, limit := defaultScheduledTransfersPage, defaultScheduledTransfersLimit
+	if req.Page != nil {
+		page = req.GetPage()
+	}
+	if req.Limit != nil {
+		limit = req.GetLimit()
+	}
+	scheduledTransfers, err := server.Store.ListScheduledTransfers(ctx, db.ListScheduledTransfersParams{
+		Owner:  int64(payload.UserID),
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when listing scheduled transfers %v", err)
+	}
+
+	data := make([]*pb.ScheduledTransfer, 0, len(scheduledTransfers))
+	for _, scheduledTransfer := range scheduledTransfers {
+		data = append(data, ConvertScheduledTransfer(scheduledTransfer))
+	}
+	res := &pb.ListScheduledTransfersRes{
+		Status: "Get scheduled transfers list successfully",
+		Data:   data,
+	}
+	return res, nil
+}
+
+func (server *Server) UpdateScheduledTransfer(ctx context.Context, req *pb.UpdateScheduledTransferReq) (*pb.UpdateScheduledTransferRes, error) {
+	violations := validateUpdateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledTransfer, err := server.Store.UpdateScheduledTransferTx(ctx, db.UpdateScheduledTransferTxParams{
+		ID: req.GetId(),
+		Update: func(scheduledTransfer *db.ScheduledTransfer) error {
+			if scheduledTransfer.Owner != int64(payload.UserID) {
+				return status.Errorf(codes.PermissionDenied, "scheduled transfer doesn't belong to that user")
+			}
+			return applyScheduledTransferUpdate(scheduledTransfer, req, time.Now())
+		},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "scheduled transfer not found")
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "update scheduled transfer failed %v", err)
+	}
+
+	res := &pb.UpdateScheduledTransferRes{
+		Status: "Update scheduled transfer successfully",
+		Data:   ConvertScheduledTransfer(scheduledTransfer),
+	}
+	return res, nil
+}
+
+// applyScheduledTransferUpdate patches the schedule with the fields set on
+// req.
+func applyScheduledTransferUpdate(scheduledTransfer *db.ScheduledTransfer, req *pb.UpdateScheduledTransferReq, now time.Time) error {
+	if req.Amount != nil {
+		scheduledTransfer.Amount = req.GetAmount()
+	}
+	if req.EndAt != nil {
+		scheduledTransfer.EndAt = sql.NullTime{Time: req.GetEndAt().AsTime(), Valid: true}
+	}
+
+	// previous anchors interval schedules, a new schedule starts from now and
+	// a resumed one keeps its alignment but skips the runs missed while paused
+	var previous time.Time
+	if req.CronExpression != nil || req.IntervalSeconds != nil {
+		scheduledTransfer.CronExpression = sql.NullString{String: req.GetCronExpression(), Valid: req.GetCronExpression() != ""}
+		scheduledTransfer.IntervalSeconds = sql.NullInt64{Int64: req.GetIntervalSeconds(), Valid: req.GetIntervalSeconds() != 0}
+		previous = now
+	}
+	if req.Status != nil {
+		newStatus := db.ScheduledTransferStatus(req.GetStatus())
+		if newStatus == db.ScheduledTransferStatusActive && scheduledTransfer.Status != db.ScheduledTransferStatusActive {
+			scheduledTransfer.ConsecutiveFailures = 0
+			if previous.IsZero() && !scheduledTransfer.NextRunAt.After(now) {
+				previous = scheduledTransfer.NextRunAt
+			}
+		}
+		scheduledTransfer.Status = newStatus
+	}
+	if !previous.IsZero() {
+		runSchedule, err := schedule.Parse(scheduledTransfer.CronExpression.String, scheduledTransfer.IntervalSeconds.Int64)
+		if err != nil {
+			return status.Errorf(codes.Internal, "stored schedule is invalid %v", err)
+		}
+		scheduledTransfer.NextRunAt = runSchedule.Next(previous, now)
+	}
+
+	if scheduledTransfer.Status == db.ScheduledTransferStatusActive &&
+		scheduledTransfer.EndAt.Valid && scheduledTransfer.NextRunAt.After(scheduledTransfer.EndAt.Time) {
+		return invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("end_at", fmt.Errorf("must not be before the next run at %s", scheduledTransfer.NextRunAt.UTC().Format(time.RFC3339))),
+		})
+	}
+	return nil
+}
+
+func (server *Server) DeleteScheduledTransfer(ctx context.Context, req *pb.DeleteScheduledTransferReq) (*pb.DeleteScheduledTransferRes, error) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := server.getOwnedScheduledTransfer(ctx, req.GetId(), payload); err != nil {
+		return nil, err
+	}
+	if err := server.Store.DeleteScheduledTransfer(ctx, req.GetId()); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete scheduled transfer failed %v", err)
+	}
+
+	res := &pb.DeleteScheduledTransferRes{
+		Status: "Delete scheduled transfer successfully",
+	}
+	return res, nil
+}
+
+func (server *Server) ListScheduledTransferAttempts(ctx context.Context, req *pb.ListScheduledTransferAttemptsReq) (*pb.ListScheduledTransferAttemptsRes, error) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.Page != nil {
+		if err := val.ValidatePage(req.GetPage()); err != nil {
+			violations = append(violations, fieldViolation("page", err))
+		}
+	}
+	if req.Limit != nil {
+		if err := val.ValidateLimit(req.GetLimit()); err != nil {
+			violations = append(violations, fieldViolation("limit", err))
+		}
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := server.getOwnedScheduledTransfer(ctx, req.GetId(), payload); err != nil {
+		return nil, err
+	}
+	page, limit := defaultScheduledTransfersPage, defaultScheduledTransfersLimit
+	if req.Page != nil {
+		page = req.GetPage()
+	}
+	if req.Limit != nil {
+		limit = req.GetLimit()
+	}
+	attempts, err := server.Store.ListScheduledTransferAttempts(ctx, db.ListScheduledTransferAttemptsParams{
+		ScheduledTransferID: req.GetId(),
+		Limit:               limit,
+		Offset:              (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when listing scheduled transfer attempts %v", err)
+	}
+
+	data := make([]*pb.ScheduledTransferAttempt, 0, len(attempts))
+	for _, attempt := range attempts {
+		data = append(data, ConvertScheduledTransferAttempt(attempt))
+	}
+	res := &pb.ListScheduledTransferAttemptsRes{
+		Status: "Get scheduled transfer attempts successfully",
+		Data:   data,
+	}
+	return res, nil
+}
+
+func (server *Server) getOwnedScheduledTransfer(ctx context.Context, id int64, payload *token.Payload) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := server.Store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return scheduledTransfer, status.Errorf(codes.NotFound, "scheduled transfer not found")
+		}
+		return scheduledTransfer, status.Errorf(codes.Internal, "error when getting scheduled transfer %v", err)
+	}
+	if scheduledTransfer.Owner != int64(payload.UserID) {
+		return scheduledTransfer, status.Errorf(codes.PermissionDenied, "scheduled transfer doesn't belong to that user")
+	}
+	return scheduledTransfer, nil
+}
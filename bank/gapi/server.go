@@ -4,6 +4,10 @@ import (
 	"fmt"
 	db "main/db/sqlc"
 	"main/pb"
+	"main/pkg/authz"
+	"main/pkg/exchange"
+	"main/pkg/oidc"
+	"main/pkg/throttle"
 	"main/token"
 	"main/util"
 	"main/worker"
@@ -15,13 +19,21 @@ type Server struct {
 	TokenMaker      token.Maker
 	Store           db.Store
 	TaskDistributor worker.TaskDistributor
+	RateProvider    exchange.ExchangeRateProvider
+	LoginThrottle   *throttle.LoginThrottle
+	Authorizer      *authz.Authorizer
+	// OIDCClient is nil unless an identity provider is configured
+	OIDCClient *oidc.Client
 }
 
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, tokenMaker token.Maker, authorizer *authz.Authorizer) (*Server, error) {
+	rateProvider, err := exchange.NewRateProvider(config)
 	if err != nil {
-		return nil, fmt.Errorf("cannot create token maker: %w", err)
+		return nil, fmt.Errorf("cannot create exchange rate provider: %w", err)
+	}
+	server := Server{Store: store, TokenMaker: tokenMaker, Config: config, TaskDistributor: taskDistributor, RateProvider: rateProvider, LoginThrottle: throttle.NewLoginThrottle(store, config), Authorizer: authorizer}
+	if config.OIDCIssuerURL != "" {
+		server.OIDCClient = oidc.NewClient(config)
 	}
-	server := Server{Store: store, TokenMaker: tokenMaker, Config: config, TaskDistributor: taskDistributor}
 	return &server, nil
 }
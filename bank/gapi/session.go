@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"main/pb"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListMySessions(ctx context.Context, req *pb.ListMySessionsReq) (*pb.ListMySessionsRes, error) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := server.Store.ListActiveSessions(ctx, int64(payload.UserID))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when listing sessions %v", err)
+	}
+
+	data := make([]*pb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, ConvertSession(session, payload.SessionID))
+	}
+	res := &pb.ListMySessionsRes{
+		Status: "List sessions successfully",
+		Data:   data,
+	}
+	return res, nil
+}
+
+// RevokeSession blocks the whole family of the session, so tokens issued
+// before its refresh token was last rotated stop working as well.
+func (server *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionReq) (*pb.RevokeSessionRes, error) {
+	sessionID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("id", fmt.Errorf("must be a valid session id")),
+		})
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := server.Store.GetSession(ctx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error when getting session %v", err)
+	}
+	if row.Session.UserID != int64(payload.UserID) {
+		return nil, status.Errorf(codes.NotFound, "session not found")
+	}
+	if err := server.Store.BlockSessionFamily(ctx, row.Session.FamilyID); err != nil {
+		return nil, status.Errorf(codes.Internal, "revoke session failed %v", err)
+	}
+
+	res := &pb.RevokeSessionRes{
+		Status: "Revoke session successfully",
+	}
+	return res, nil
+}
+
+func (server *Server) LogoutAllSessions(ctx context.Context, req *pb.LogoutAllSessionsReq) (*pb.LogoutAllSessionsRes, error) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := server.Store.BlockUserSessions(ctx, int64(payload.UserID)); err != nil {
+		return nil, status.Errorf(codes.Internal, "logout all sessions failed %v", err)
+	}
+
+	res := &pb.LogoutAllSessionsRes{
+		Status: "Logout all sessions successfully",
+	}
+	return res, nil
+}
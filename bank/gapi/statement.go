@@ -0,0 +1,139 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/statement"
+	"main/pkg/val"
+	"main/worker"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxStatementPeriod = 366 * 24 * time.Hour
+
+type statementRequest interface {
+	GetId() int64
+	GetFormat() string
+	GetFromTime() *timestamppb.Timestamp
+	GetToTime() *timestamppb.Timestamp
+}
+
+func validateStatementRequest(req statementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.GetFormat() != "" {
+		if _, err := statement.ParseFormat(req.GetFormat()); err != nil {
+			violations = append(violations, fieldViolation("format", err))
+		}
+	}
+	if req.GetFromTime() == nil {
+		violations = append(violations, fieldViolation("from_time", fmt.Errorf("is required")))
+	}
+	if req.GetToTime() == nil {
+		violations = append(violations, fieldViolation("to_time", fmt.Errorf("is required")))
+	}
+	if req.GetFromTime() != nil && req.GetToTime() != nil {
+		period := req.GetToTime().AsTime().Sub(req.GetFromTime().AsTime())
+		if period <= 0 {
+			violations = append(violations, fieldViolation("to_time", fmt.Errorf("must be after from_time")))
+		} else if period > maxStatementPeriod {
+			violations = append(violations, fieldViolation("to_time", fmt.Errorf("statement period must not exceed %d days", maxStatementPeriod/(24*time.Hour))))
+		}
+	}
+	return violations
+}
+
+func statementFormat(req statementRequest) statement.Format {
+	if req.GetFormat() == "" {
+		return statement.FormatCSV
+	}
+	format, _ := statement.ParseFormat(req.GetFormat())
+	return format
+}
+
+func (server *Server) GetAccountStatement(ctx context.Context, req *pb.GetAccountStatementReq) (*httpbody.HttpBody, error) {
+	violations := validateStatementRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := server.getReadableAccount(ctx, req.GetId(), payload); err != nil {
+		return nil, err
+	}
+
+	fromTime, toTime := req.GetFromTime().AsTime(), req.GetToTime().AsTime()
+	result, err := server.Store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: req.GetId(),
+		FromTime:  fromTime,
+		ToTime:    toTime,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when getting account statement %v", err)
+	}
+
+	format := statementFormat(req)
+	accountStatement := statement.New(result, fromTime, toTime)
+	var buf bytes.Buffer
+	if err := statement.Render(&buf, accountStatement, format); err != nil {
+		return nil, status.Errorf(codes.Internal, "error when rendering account statement %v", err)
+	}
+	// forwarded as is by the gateway, see outgoingHeaderMatcher
+	header := metadata.Pairs("content-disposition", fmt.Sprintf("attachment; filename=%q", statement.FileName(accountStatement, format)))
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		return nil, status.Errorf(codes.Internal, "error when setting header %v", err)
+	}
+
+	res := &httpbody.HttpBody{
+		ContentType: statement.ContentType(format),
+		Data:        buf.Bytes(),
+	}
+	return res, nil
+}
+
+func (server *Server) EmailAccountStatement(ctx context.Context, req *pb.EmailAccountStatementReq) (*pb.EmailAccountStatementRes, error) {
+	violations := validateStatementRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := server.getReadableAccount(ctx, req.GetId(), payload); err != nil {
+		return nil, err
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(5),
+	}
+	err = server.TaskDistributor.DistributeTaskSendAccountStatement(ctx, &worker.PayloadSendAccountStatement{
+		AccountID: req.GetId(),
+		Format:    string(statementFormat(req)),
+		FromTime:  req.GetFromTime().AsTime(),
+		ToTime:    req.GetToTime().AsTime(),
+	}, opts...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when scheduling account statement email %v", err)
+	}
+
+	res := &pb.EmailAccountStatementRes{
+		Status: "Account statement will be sent to the account owner's email",
+	}
+	return res, nil
+}
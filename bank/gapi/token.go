@@ -0,0 +1,103 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/revocation"
+	"main/token"
+	"main/util"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenReq) (*pb.RenewAccessTokenRes, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("refresh_token", fmt.Errorf("is required")),
+		})
+	}
+	refreshPayload, err := server.TokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token %v", err)
+	}
+	if refreshPayload.Type != token.TokenTypeRefresh {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token %v", token.ErrWrongTokenType)
+	}
+	user, err := server.Store.GetUser(ctx, int64(refreshPayload.UserID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error when getting user %v", err)
+	}
+	if refreshPayload.IssuedAt.Before(user.PasswordChangedAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", revocation.ErrTokenRevoked)
+	}
+	if user.IsLocked {
+		return nil, status.Errorf(codes.PermissionDenied, "%v", db.ErrUserLocked)
+	}
+
+	mtdt := util.ExtractMetadata(ctx)
+	sessionID := uuid.New()
+	var refreshToken string
+	var newRefreshPayload *token.Payload
+	_, err = server.Store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		SessionID:    refreshPayload.SessionID,
+		RefreshToken: req.GetRefreshToken(),
+		NewSession: func(session db.Session) (db.CreateSessionParams, error) {
+			if session.UserID != user.UserID {
+				return db.CreateSessionParams{}, status.Errorf(codes.Unauthenticated, "incorrect session user")
+			}
+			var err error
+			refreshToken, newRefreshPayload, err = server.TokenMaker.CreateToken(token.TokenTypeRefresh, strconv.FormatInt(user.UserID, 10), user.Email, user.Role, sessionID, server.Config.RefreshTokenDuration)
+			if err != nil {
+				return db.CreateSessionParams{}, status.Errorf(codes.Internal, "error when creating refresh token %v", err)
+			}
+			return db.CreateSessionParams{
+				ID:           sessionID,
+				UserID:       user.UserID,
+				RefreshToken: refreshToken,
+				UserAgent:    mtdt.UserAgent,
+				ClientIp:     mtdt.ClientIp,
+				IsBlocked:    false,
+				ExpiredAt:    newRefreshPayload.ExpiredAt,
+			}, nil
+		},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "session not found")
+		}
+		if errors.Is(err, db.ErrRefreshTokenReused) || errors.Is(err, db.ErrSessionBlocked) ||
+			errors.Is(err, db.ErrSessionExpired) || errors.Is(err, db.ErrSessionTokenMismatch) {
+			return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "error when rotating session %v", err)
+	}
+
+	accessToken, accessPayload, err := server.TokenMaker.CreateToken(token.TokenTypeAccess, strconv.FormatInt(user.UserID, 10), user.Email, user.Role, sessionID, server.Config.TokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when creating access token %v", err)
+	}
+
+	res := &pb.RenewAccessTokenRes{
+		Status:                "Renew access token successfully",
+		AccessToken:           accessToken,
+		AccessTokenExpiredAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiredAt: timestamppb.New(newRefreshPayload.ExpiredAt),
+	}
+	return res, nil
+}
@@ -0,0 +1,33 @@
+package gapi
+
+import (
+	"context"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/token"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRenewAccessTokenRejectsAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tokenMaker, err := token.NewPasetoMaker(util.RandomStr(32))
+	require.NoError(t, err)
+	server := &Server{Config: util.Config{TokenDuration: time.Minute, RefreshTokenDuration: time.Hour}, Store: store, TokenMaker: tokenMaker}
+
+	accessToken, _, err := tokenMaker.CreateToken(token.TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	// rejected before the session is looked up
+	_, err = server.RenewAccessToken(context.Background(), &pb.RenewAccessTokenReq{RefreshToken: accessToken})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
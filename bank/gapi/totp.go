@@ -0,0 +1,193 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/totp"
+	"main/util"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const totpIssuer = "Simple Bank"
+
+func validateConfirmTOTPRequest(req *pb.ConfirmTOTPReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if len(req.GetCode()) != totp.Digits {
+		violations = append(violations, fieldViolation("code", fmt.Errorf("must contain %d digits", totp.Digits)))
+	}
+	return violations
+}
+
+func validateLoginUserMFARequest(req *pb.LoginUserMFAReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetChallengeToken() == "" {
+		violations = append(violations, fieldViolation("challenge_token", fmt.Errorf("is required")))
+	}
+	if (req.GetCode() == "") == (req.GetRecoveryCode() == "") {
+		violations = append(violations, fieldViolation("code", fmt.Errorf("exactly one of code or recovery_code is required")))
+	}
+	return violations
+}
+
+// SetupTOTP generates a new secret for the user, two-factor authentication
+// only turns on once ConfirmTOTP receives a code made from it.
+func (server *Server) SetupTOTP(ctx context.Context, req *pb.SetupTOTPReq) (*pb.SetupTOTPRes, error) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	// the upsert leaves an enabled credential untouched and returns no row
+	_, err = server.Store.UpsertTotpCredential(ctx, db.UpsertTotpCredentialParams{
+		UserID: int64(payload.UserID),
+		Secret: secret,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", db.ErrTOTPAlreadyEnabled)
+		}
+		return nil, status.Errorf(codes.Internal, "setup totp failed %v", err)
+	}
+
+	res := &pb.SetupTOTPRes{
+		Status:     "Setup totp successfully",
+		Secret:     secret,
+		OtpauthUri: totp.URI(totpIssuer, payload.Email, secret),
+	}
+	return res, nil
+}
+
+// ConfirmTOTP enables two-factor authentication and returns the recovery
+// codes, they are only ever shown here.
+func (server *Server) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPReq) (*pb.ConfirmTOTPRes, error) {
+	violations := validateConfirmTOTPRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes(totp.RecoveryCodeCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	recoveryCodeHashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		recoveryCodeHashes[i] = totp.HashRecoveryCode(code)
+	}
+
+	_, err = server.Store.EnableTOTPTx(ctx, db.EnableTOTPTxParams{
+		UserID: int64(payload.UserID),
+		VerifyCode: func(credential db.TotpCredential) (int64, error) {
+			return totp.Validate(req.GetCode(), credential.Secret, time.Now())
+		},
+		RecoveryCodeHashes: recoveryCodeHashes,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.FailedPrecondition, "totp has to be set up first")
+		}
+		if errors.Is(err, db.ErrTOTPAlreadyEnabled) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		if errors.Is(err, db.ErrInvalidMfaCode) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("code", err)})
+		}
+		return nil, status.Errorf(codes.Internal, "confirm totp failed %v", err)
+	}
+
+	res := &pb.ConfirmTOTPRes{
+		Status:        "Confirm totp successfully",
+		RecoveryCodes: recoveryCodes,
+	}
+	return res, nil
+}
+
+// startMfaChallenge answers LoginUser for a user with two-factor
+// authentication, the challenge token is exchanged by LoginUserMFA.
+func (server *Server) startMfaChallenge(ctx context.Context, user db.User) (*pb.LoginUserRes, error) {
+	challengeToken, err := util.NewSecretToken(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	_, err = server.Store.StartMfaChallengeTx(ctx, db.CreateMfaChallengeParams{
+		UserID:    user.UserID,
+		TokenHash: util.HashSecretToken(challengeToken),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error when creating mfa challenge %v", err)
+	}
+
+	res := &pb.LoginUserRes{
+		Status:            "Two-factor authentication required",
+		MfaRequired:       true,
+		MfaChallengeToken: challengeToken,
+	}
+	return res, nil
+}
+
+func (server *Server) LoginUserMFA(ctx context.Context, req *pb.LoginUserMFAReq) (*pb.LoginUserRes, error) {
+	violations := validateLoginUserMFARequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	tokenHash := util.HashSecretToken(req.GetChallengeToken())
+	challengeUser, err := server.Store.GetUserByMfaChallenge(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "login challenge not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error when getting mfa challenge %v", err)
+	}
+	mtdt := util.ExtractMetadata(ctx)
+	if err := server.checkLoginThrottle(ctx, challengeUser.Email, mtdt.ClientIp); err != nil {
+		return nil, err
+	}
+
+	arg := db.CompleteMfaChallengeTxParams{
+		TokenHash: tokenHash,
+		VerifyCode: func(credential db.TotpCredential) (int64, error) {
+			return totp.Validate(req.GetCode(), credential.Secret, time.Now())
+		},
+	}
+	if req.GetRecoveryCode() != "" {
+		arg.RecoveryCodeHash = totp.HashRecoveryCode(req.GetRecoveryCode())
+	}
+	user, err := server.Store.CompleteMfaChallengeTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "login challenge not found")
+		}
+		// wrong codes count like wrong passwords, a new challenge doesn't
+		// give more guesses
+		if errors.Is(err, db.ErrInvalidMfaCode) {
+			if err := server.recordLoginFailure(ctx, challengeUser.Email, mtdt.ClientIp, &challengeUser); err != nil {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+		}
+		if errors.Is(err, db.ErrMfaChallengeUsed) || errors.Is(err, db.ErrMfaChallengeExpired) ||
+			errors.Is(err, db.ErrMfaChallengeTooMany) || errors.Is(err, db.ErrTOTPNotEnabled) {
+			return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "login failed %v", err)
+	}
+	if user.IsLocked {
+		return nil, status.Errorf(codes.PermissionDenied, "%v", db.ErrUserLocked)
+	}
+
+	return server.createLoginSession(ctx, user)
+}
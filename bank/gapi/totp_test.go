@@ -0,0 +1,96 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/throttle"
+	"main/util"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserMFAWrongCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	config := util.Config{LoginMaxAttempts: 5, LoginMaxAttemptsPerIP: 20}
+	server := &Server{Config: config, Store: store, LoginThrottle: throttle.NewLoginThrottle(store, config)}
+
+	user := db.User{UserID: 1, Email: util.RandomEmail()}
+	challengeToken := util.RandomStr(32)
+	tokenHash := util.HashSecretToken(challengeToken)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 52814}})
+
+	store.EXPECT().GetUserByMfaChallenge(gomock.Any(), tokenHash).Return(user, nil)
+	store.EXPECT().GetLoginAttempt(gomock.Any(), gomock.Any()).AnyTimes().Return(db.LoginAttempt{}, sql.ErrNoRows)
+	store.EXPECT().CompleteMfaChallengeTx(gomock.Any(), gomock.Any()).
+		Return(db.User{}, fmt.Errorf("%w: wrong code", db.ErrInvalidMfaCode))
+
+	// the wrong code counts against the email and the client ip like a
+	// wrong password
+	store.EXPECT().RecordFailedLogin(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, arg db.RecordFailedLoginParams) (db.LoginAttempt, error) {
+			switch arg.Scope {
+			case throttle.ScopeEmail:
+				require.Equal(t, user.Email, arg.Identifier)
+			case throttle.ScopeIP:
+				require.Equal(t, "10.0.0.1", arg.Identifier)
+			}
+			return db.LoginAttempt{FailedCount: 1}, nil
+		})
+	store.EXPECT().LockLogin(gomock.Any(), gomock.Any()).Times(2)
+
+	_, err := server.LoginUserMFA(ctx, &pb.LoginUserMFAReq{ChallengeToken: challengeToken, Code: "123456"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestLoginUserMFALockedOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	config := util.Config{}
+	server := &Server{Config: config, Store: store, LoginThrottle: throttle.NewLoginThrottle(store, config)}
+
+	user := db.User{UserID: 1, Email: util.RandomEmail()}
+	challengeToken := util.RandomStr(32)
+
+	// a locked out email gets no more guesses at the code
+	store.EXPECT().GetUserByMfaChallenge(gomock.Any(), util.HashSecretToken(challengeToken)).Return(user, nil)
+	store.EXPECT().GetLoginAttempt(gomock.Any(), db.GetLoginAttemptParams{Scope: throttle.ScopeEmail, Identifier: user.Email}).
+		Return(db.LoginAttempt{LockedUntil: time.Now().Add(time.Minute)}, nil)
+
+	_, err := server.LoginUserMFA(context.Background(), &pb.LoginUserMFAReq{ChallengeToken: challengeToken, Code: "123456"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLoginUserKeepsFailuresUntilMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	config := util.Config{}
+	server := &Server{Config: config, Store: store, LoginThrottle: throttle.NewLoginThrottle(store, config)}
+
+	password := util.RandomStr(12)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+	user := db.User{UserID: 1, Email: util.RandomEmail(), HashedPassword: hashedPassword}
+
+	// the right password alone doesn't forget the failed logins, no
+	// DeleteLoginAttempt is expected
+	store.EXPECT().GetLoginAttempt(gomock.Any(), gomock.Any()).AnyTimes().Return(db.LoginAttempt{}, sql.ErrNoRows)
+	store.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil)
+	store.EXPECT().GetTotpCredential(gomock.Any(), user.UserID).Return(db.TotpCredential{UserID: user.UserID, IsEnabled: true}, nil)
+	store.EXPECT().StartMfaChallengeTx(gomock.Any(), gomock.Any()).Return(db.MfaChallenge{}, nil)
+
+	res, err := server.LoginUser(context.Background(), &pb.LoginUserReq{Email: user.Email, Password: password})
+	require.NoError(t, err)
+	require.True(t, res.GetMfaRequired())
+}
@@ -0,0 +1,160 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/exchange"
+	"main/pkg/val"
+	"main/util"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func validateTransferMoneyRequest(req *pb.TransferMoneyReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from from_account_id")))
+	}
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	return violations
+}
+
+func (server *Server) TransferMoney(ctx context.Context, req *pb.TransferMoneyReq) (*pb.TransferMoneyRes, error) {
+	violations := validateTransferMoneyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAccount, err := server.getTransferAccount(ctx, req.GetFromAccountId(), "from_account_id")
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Owner != int64(payload.UserID) {
+		return nil, status.Errorf(codes.PermissionDenied, "from account doesn't belong to that user")
+	}
+	if err := checkAccountCurrency(fromAccount, req.GetCurrency(), "from_account_id"); err != nil {
+		return nil, err
+	}
+	toAccount, err := server.getTransferAccount(ctx, req.GetToAccountId(), "to_account_id")
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountId: req.GetFromAccountId(),
+		ToAccountId:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+	}
+	if toAccount.Currency != fromAccount.Currency {
+		if err := server.convertTransfer(ctx, &arg, fromAccount.Currency, toAccount.Currency); err != nil {
+			return nil, err
+		}
+	}
+	var result db.TransferTxResult
+	if idempotencyKey := util.ExtractMetadata(ctx).IdempotencyKey; idempotencyKey != "" {
+		result, err = server.idempotentTransfer(ctx, req, arg, int64(payload.UserID), idempotencyKey)
+	} else {
+		result, err = server.Store.TransferTx(ctx, arg)
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		}
+		if errors.Is(err, db.ErrInsufficientFunds) || errors.Is(err, db.ErrAccountFrozen) || errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "transfer failed %v", err)
+	}
+
+	res := &pb.TransferMoneyRes{
+		Status: "Create transfer successfully",
+		Data:   ConvertTransferTxResult(result),
+	}
+	return res, nil
+}
+
+func (server *Server) idempotentTransfer(ctx context.Context, req *pb.TransferMoneyReq, arg db.TransferTxParams, userID int64, idempotencyKey string) (db.TransferTxResult, error) {
+	if err := val.ValidateIdempotencyKey(idempotencyKey); err != nil {
+		return db.TransferTxResult{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation(util.IdempotencyKey, err),
+		})
+	}
+	requestHash, err := util.HashRequest(req)
+	if err != nil {
+		return db.TransferTxResult{}, status.Errorf(codes.Internal, "%v", err)
+	}
+	return server.Store.IdempotentTransferTx(ctx, db.IdempotentTransferTxParams{
+		TransferTxParams: arg,
+		UserID:           userID,
+		IdempotencyKey:   idempotencyKey,
+		RequestHash:      requestHash,
+		Window:           server.Config.IdempotencyKeyTTL,
+	})
+}
+
+// convertTransfer prices the transfer in the destination account's currency.
+func (server *Server) convertTransfer(ctx context.Context, arg *db.TransferTxParams, from string, to string) error {
+	rate, err := server.RateProvider.GetRate(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, exchange.ErrRateNotFound) {
+			return status.Errorf(codes.FailedPrecondition, "cannot transfer from %s to %s: %v", from, to, err)
+		}
+		return status.Errorf(codes.Internal, "error when getting exchange rate %v", err)
+	}
+	conversion := exchange.Convert(arg.Amount, from, to, rate)
+	if conversion.ConvertedAmount <= 0 {
+		return invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("amount", fmt.Errorf("amount is too small to convert from %s to %s", from, to)),
+		})
+	}
+	arg.ExchangeRate = conversion.RateString()
+	arg.ConvertedAmount = conversion.ConvertedAmount
+	return nil
+}
+
+// getTransferAccount loads an account taking part in a transfer, reporting a
+// field violation on field when it doesn't exist.
+func (server *Server) getTransferAccount(ctx context.Context, accountID int64, field string) (db.Account, error) {
+	account, err := server.Store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+				fieldViolation(field, fmt.Errorf("account %d doesn't exist", accountID)),
+			})
+		}
+		return account, status.Errorf(codes.Internal, "error when getting account %v", err)
+	}
+	return account, nil
+}
+
+func checkAccountCurrency(account db.Account, currency string, field string) error {
+	if account.Currency != currency {
+		return invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation(field, fmt.Errorf("account currency mismatch: %s vs %s", account.Currency, currency)),
+		})
+	}
+	return nil
+}
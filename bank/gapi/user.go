@@ -3,14 +3,18 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"errors"
 	db "main/db/sqlc"
 	"main/pb"
+	"main/pkg/throttle"
 	"main/pkg/val"
+	"main/token"
 	"main/util"
 	"main/worker"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -111,24 +115,105 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserReq) (*pb.
 	if violations != nil {
 		return nil, invalidArgumentError(violations)
 	}
+	mtdt := util.ExtractMetadata(ctx)
+	if err := server.checkLoginThrottle(ctx, req.Email, mtdt.ClientIp); err != nil {
+		return nil, err
+	}
 	user, err := server.Store.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found %v", err)
+			util.VerifyDummyPassword(req.Password)
+			return nil, server.failLogin(ctx, req.Email, mtdt.ClientIp, nil)
 		}
 		return nil, status.Errorf(codes.Internal, "error when getting user %v", err)
 	}
+	// a locked user gets the same answer as a wrong password, before the
+	// password is checked, so it can't be used to confirm a password
+	if user.IsLocked {
+		util.VerifyDummyPassword(req.Password)
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect email or password")
+	}
 
 	err = util.VerifyPassword(req.Password, user.HashedPassword)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "password isn't correct %v", err)
+		return nil, server.failLogin(ctx, req.Email, mtdt.ClientIp, &user)
+	}
+	return server.finishLogin(ctx, user)
+}
+
+// checkLoginThrottle refuses a login step while the email or the client ip
+// is locked out.
+func (server *Server) checkLoginThrottle(ctx context.Context, email, clientIP string) error {
+	if err := server.LoginThrottle.Check(ctx, email, clientIP); err != nil {
+		var lockedErr *throttle.LockedError
+		if errors.As(err, &lockedErr) {
+			return status.Errorf(codes.ResourceExhausted, "%v", err)
+		}
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	return nil
+}
+
+// finishLogin logs in a user whose identity was checked, users with
+// two-factor authentication still have to answer a challenge.
+func (server *Server) finishLogin(ctx context.Context, user db.User) (*pb.LoginUserRes, error) {
+	if user.IsLocked {
+		return nil, status.Errorf(codes.PermissionDenied, "%v", db.ErrUserLocked)
+	}
+	credential, err := server.Store.GetTotpCredential(ctx, user.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, status.Errorf(codes.Internal, "error when getting totp credential %v", err)
 	}
-	accessToken, _, err := server.TokenMaker.CreateToken(strconv.FormatInt(user.UserID, 10), user.Email, user.Role, server.Config.TokenDuration)
+	if err == nil && credential.IsEnabled {
+		return server.startMfaChallenge(ctx, user)
+	}
+	return server.createLoginSession(ctx, user)
+}
+
+// failLogin records a wrong email or password and answers the same for
+// both, so LoginUser doesn't tell which emails have an account.
+func (server *Server) failLogin(ctx context.Context, email, clientIP string, user *db.User) error {
+	if err := server.recordLoginFailure(ctx, email, clientIP, user); err != nil {
+		return err
+	}
+	return status.Errorf(codes.Unauthenticated, "incorrect email or password")
+}
+
+// recordLoginFailure counts a wrong password or two-factor code against the
+// email and the client ip. The user is told by email when their account
+// gets locked.
+func (server *Server) recordLoginFailure(ctx context.Context, email, clientIP string, user *db.User) error {
+	lockedOut, err := server.LoginThrottle.Fail(ctx, email, clientIP)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	if lockedOut && user != nil {
+		err = server.TaskDistributor.DistributeTaskSendLoginLockoutNotice(ctx, &worker.PayloadSendLoginLockoutNotice{
+			UserID:      user.UserID,
+			ClientIp:    clientIP,
+			LockedUntil: time.Now().Add(server.Config.LoginLockoutDuration).Format(time.RFC1123),
+		}, asynq.MaxRetry(10))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to distribute task %v", err)
+		}
+	}
+	return nil
+}
+
+// createLoginSession issues the access and refresh tokens of a user who
+// completed every login step, only then are the failed logins of the email
+// forgotten.
+func (server *Server) createLoginSession(ctx context.Context, user db.User) (*pb.LoginUserRes, error) {
+	if err := server.LoginThrottle.Succeed(ctx, user.Email); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	sessionID := uuid.New()
+	accessToken, _, err := server.TokenMaker.CreateToken(token.TokenTypeAccess, strconv.FormatInt(user.UserID, 10), user.Email, user.Role, sessionID, server.Config.TokenDuration)
 	if err != nil {
 
 		return nil, status.Errorf(codes.Internal, "error when creating access token %v", err)
 	}
-	refreshToken, refreshPayload, err := server.TokenMaker.CreateToken(strconv.FormatInt(user.UserID, 10), user.Email, user.Role, server.Config.RefreshTokenDuration)
+	refreshToken, refreshPayload, err := server.TokenMaker.CreateToken(token.TokenTypeRefresh, strconv.FormatInt(user.UserID, 10), user.Email, user.Role, sessionID, server.Config.RefreshTokenDuration)
 
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error when creating refresh token %v", err)
@@ -136,13 +221,14 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserReq) (*pb.
 
 	mtdt := util.ExtractMetadata(ctx)
 	_, err = server.Store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
+		ID:           sessionID,
 		UserID:       int64(refreshPayload.UserID),
 		RefreshToken: refreshToken,
 		UserAgent:    mtdt.UserAgent,
 		ClientIp:     mtdt.ClientIp,
 		IsBlocked:    false,
 		ExpiredAt:    refreshPayload.ExpiredAt,
+		FamilyID:     sessionID,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error when creating session %v", err)
@@ -165,8 +251,12 @@ func (server *Server) UpdateMe(ctx context.Context, req *pb.UpdateUserReq) (*pb.
 	if err != nil {
 		return nil, err
 	}
+	if req.GetEmail() != "" {
+		if err := server.changeEmail(ctx, int64(payload.UserID), req.GetEmail()); err != nil {
+			return nil, err
+		}
+	}
 	params := db.UpdateUserParams{
-		Email:    sql.NullString{String: req.GetEmail(), Valid: req.GetEmail() != ""},
 		FullName: sql.NullString{String: req.GetFullname(), Valid: req.GetFullname() != ""},
 		UserID:   int64(payload.UserID),
 	}
@@ -190,3 +280,36 @@ func (server *Server) UpdateMe(ctx context.Context, req *pb.UpdateUserReq) (*pb.
 	}
 	return res, nil
 }
+
+// changeEmail stages the new email until it's confirmed from the link sent to
+// it, the current address is told about the change.
+func (server *Server) changeEmail(ctx context.Context, userID int64, newEmail string) error {
+	_, err := server.Store.ChangeEmailTx(ctx, db.ChangeEmailTxParams{
+		UserID:   userID,
+		NewEmail: newEmail,
+		AfterUpdate: func(user db.User) error {
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+			}
+			err := server.TaskDistributor.DistributeTaskSendVerifyEmail(ctx, &worker.PayloadSendVerifyEmail{
+				UserID: user.UserID,
+				Email:  newEmail,
+			}, opts...)
+			if err != nil {
+				return err
+			}
+			return server.TaskDistributor.DistributeTaskSendEmailChangeNotice(ctx, &worker.PayloadSendEmailChangeNotice{
+				UserID:   user.UserID,
+				OldEmail: user.Email,
+				NewEmail: newEmail,
+			}, opts...)
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrEmailTaken) || db.ErrorCode(err) == db.UniqueViolation {
+			return status.Errorf(codes.AlreadyExists, "%v", db.ErrEmailTaken)
+		}
+		return status.Errorf(codes.Internal, "change email failed %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/throttle"
+	"main/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	config := util.Config{}
+	server := &Server{Config: config, Store: store, LoginThrottle: throttle.NewLoginThrottle(store, config)}
+
+	password := util.RandomStr(12)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+	user := db.User{UserID: 1, Email: util.RandomEmail(), HashedPassword: hashedPassword, IsLocked: true}
+
+	store.EXPECT().GetLoginAttempt(gomock.Any(), gomock.Any()).AnyTimes().Return(db.LoginAttempt{}, sql.ErrNoRows)
+	store.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil)
+
+	// the right password gets the same answer as a wrong one
+	_, err = server.LoginUser(context.Background(), &pb.LoginUserReq{Email: user.Email, Password: password})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+	require.Equal(t, "incorrect email or password", st.Message())
+}
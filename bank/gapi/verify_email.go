@@ -0,0 +1,111 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/pkg/val"
+	"main/worker"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func validateVerifyEmailRequest(req *pb.VerifyEmailReq) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateEmailId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if err := val.ValidateSecretCode(req.GetSecretCode()); err != nil {
+		violations = append(violations, fieldViolation("secret_code", err))
+	}
+	return violations
+}
+
+func (server *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailReq) (*pb.VerifyEmailRes, error) {
+	violations := validateVerifyEmailRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.Store.VerifyEmailTx(ctx, db.VerifyEmailTxParams{
+		EmailID:    req.GetId(),
+		SecretCode: req.GetSecretCode(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows || errors.Is(err, db.ErrVerifyEmailMismatch) {
+			return nil, status.Errorf(codes.NotFound, "verification code not found")
+		}
+		if errors.Is(err, db.ErrVerifyEmailUsed) || errors.Is(err, db.ErrVerifyEmailExpired) ||
+			errors.Is(err, db.ErrVerifyEmailOutdated) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "%v", db.ErrEmailTaken)
+		}
+		return nil, status.Errorf(codes.Internal, "verify email failed %v", err)
+	}
+
+	res := &pb.VerifyEmailRes{
+		Status:          "Verify email successfully",
+		IsEmailVerified: result.User.IsEmailVerified,
+	}
+	return res, nil
+}
+
+// ResendVerifyEmail sends a new verification code, at most once per
+// VerifyEmailInterval for each user.
+func (server *Server) ResendVerifyEmail(ctx context.Context, req *pb.ResendVerifyEmailReq) (*pb.ResendVerifyEmailRes, error) {
+	payload, err := GetAuthPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := server.Store.GetUser(ctx, int64(payload.UserID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "error when getting user %v", err)
+	}
+	// a pending email is verified before the current one
+	email := ""
+	if user.PendingEmail.Valid {
+		email = user.PendingEmail.String
+	} else if user.IsEmailVerified {
+		return nil, status.Errorf(codes.FailedPrecondition, "email is already verified")
+	}
+
+	latest, err := server.Store.GetLatestVerifyEmail(ctx, user.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, status.Errorf(codes.Internal, "error when getting verify email %v", err)
+	}
+	if err == nil && time.Since(latest.CreatedAt) < server.Config.VerifyEmailInterval {
+		return nil, status.Errorf(codes.ResourceExhausted, "verification email was sent recently, try again later")
+	}
+
+	// the code is only created once the task runs, Unique covers that gap
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Unique(server.Config.VerifyEmailInterval),
+	}
+	err = server.TaskDistributor.DistributeTaskSendVerifyEmail(ctx, &worker.PayloadSendVerifyEmail{
+		UserID: user.UserID,
+		Email:  email,
+	}, opts...)
+	if err != nil {
+		if errors.Is(err, asynq.ErrDuplicateTask) {
+			return nil, status.Errorf(codes.ResourceExhausted, "verification email was sent recently, try again later")
+		}
+		return nil, status.Errorf(codes.Internal, "error when sending verify email %v", err)
+	}
+
+	res := &pb.ResendVerifyEmailRes{
+		Status: "Resend verify email successfully",
+	}
+	return res, nil
+}
@@ -7,14 +7,17 @@ import (
 	db "main/db/sqlc"
 	"main/gapi"
 	"main/pb"
+	"main/pkg/authz"
 	"main/pkg/interceptors"
 	"main/pkg/log"
 	pkg "main/pkg/mail"
 	"main/pkg/val"
+	"main/token"
 	"main/util"
 	"main/worker"
 	"net"
 	"net/http"
+	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/hibiken/asynq"
@@ -30,6 +33,10 @@ func main() {
 		log.Logger.Fatal("Error when loading env!!", err)
 	}
 	val.RegisterCustomValidations()
+	err = util.SetTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		log.Logger.Fatal("Invalid trusted proxies")
+	}
 	conn, err := sql.Open(config.DbDriver, config.DbSource)
 	if err != nil {
 		log.Logger.Fatal("Error when connecting to db!!", err)
@@ -40,14 +47,31 @@ func main() {
 		Addr: config.RedisAddress,
 	}
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
-	go runGrpcServer(config, store, taskDistributor)
+	tokenMaker, err := token.NewKeyring(config)
+	if err != nil {
+		log.Logger.Fatal("Error when creating token maker!!", err)
+	}
+	// rotating the token keys only needs the .env file to be updated
+	util.WatchConfig(func(config util.Config, err error) {
+		if err == nil {
+			err = tokenMaker.Reload(config)
+		}
+		if err != nil {
+			log.Logger.Error("cannot reload token keys ", err)
+			return
+		}
+		log.Logger.Printf("reloaded token keys, signing with %s", tokenMaker.ActiveKeyID())
+	})
+	authorizer := authz.NewAuthorizer(store, authz.DefaultCacheTTL)
+	go runGrpcServer(config, store, taskDistributor, tokenMaker, authorizer)
 	go runTaskProcessor(config, redisOpt, store)
-	runGatewayServer(config, store, taskDistributor)
+	go runTaskScheduler(redisOpt)
+	runGatewayServer(config, store, taskDistributor, tokenMaker, authorizer)
 
-	//runHttpServer(config, store)
+	//runHttpServer(config, store, taskDistributor, tokenMaker, authorizer)
 }
-func runHttpServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
+func runHttpServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, tokenMaker token.Maker, authorizer *authz.Authorizer) {
+	server, err := api.NewServer(config, store, taskDistributor, tokenMaker, authorizer)
 	if err != nil {
 		log.Logger.Fatal("Error when creating server")
 	}
@@ -56,15 +80,16 @@ func runHttpServer(config util.Config, store db.Store) {
 		log.Logger.Fatal("Error when starting server")
 	}
 }
-func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, tokenMaker token.Maker, authorizer *authz.Authorizer) {
+	server, err := gapi.NewServer(config, store, taskDistributor, tokenMaker, authorizer)
 	if err != nil {
 		log.Logger.Fatal("Error when creating server")
 	}
 
-	interceptor := interceptors.NewGRPCInterceptor(server.TokenMaker)
+	interceptor := interceptors.NewGRPCInterceptor(server.TokenMaker, store, authorizer)
 	grpcServer := grpc.NewServer(interceptor.Unary())
 	pb.RegisterSimpleBankServer(grpcServer, server)
+	pb.RegisterAdminServiceServer(grpcServer, gapi.NewAdminServer(server))
 	reflection.Register(grpcServer)
 
 	listener, err := net.Listen("tcp", config.GrpcAPIEndpoint)
@@ -78,8 +103,8 @@ func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.Ta
 		log.Logger.Fatal("Cannot creating grpc server")
 	}
 }
-func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, tokenMaker *token.Keyring, authorizer *authz.Authorizer) {
+	server, err := gapi.NewServer(config, store, taskDistributor, tokenMaker, authorizer)
 	if err != nil {
 		log.Logger.Fatal("Error when creating server")
 	}
@@ -87,12 +112,17 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 	defer cancel()
 
 	grpcMux := runtime.NewServeMux(
-		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-			MarshalOptions: protojson.MarshalOptions{
-				UseProtoNames: true,
-			},
-			UnmarshalOptions: protojson.UnmarshalOptions{
-				DiscardUnknown: true,
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		// HTTPBodyMarshaler writes google.api.HttpBody responses as raw bytes
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{
+			Marshaler: &runtime.JSONPb{
+				MarshalOptions: protojson.MarshalOptions{
+					UseProtoNames: true,
+				},
+				UnmarshalOptions: protojson.UnmarshalOptions{
+					DiscardUnknown: true,
+				},
 			},
 		}))
 
@@ -101,12 +131,22 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 		log.Logger.Fatal("Error when creating gateway server")
 		return
 	}
-	interceptor := interceptors.NewGatewayInterceptor(server.TokenMaker)
+	err = pb.RegisterAdminServiceHandlerServer(ctx, grpcMux, gapi.NewAdminServer(server))
+	if err != nil {
+		log.Logger.Fatal("Error when creating gateway server")
+		return
+	}
+	interceptor := interceptors.NewGatewayInterceptor(server.TokenMaker, store, authorizer)
 
 	mux := http.NewServeMux()
 	wrappedHandler := interceptor.LoggerMiddleware(interceptor.AuthMiddleware(ctx, grpcMux))
 
 	mux.Handle("/", wrappedHandler)
+	mux.Handle("/.well-known/jwks.json", interceptor.LoggerMiddleware(tokenMaker.JWKSHandler(config.JWKSMaxAge)))
+	if server.OIDCClient != nil {
+		mux.Handle("/v1/auth/oidc/start", interceptor.LoggerMiddleware(server.OIDCStartHandler()))
+		mux.Handle("/v1/auth/oidc/callback", interceptor.LoggerMiddleware(server.OIDCCallbackHandler()))
+	}
 
 	listener, err := net.Listen("tcp", config.APIEndpoint)
 	if err != nil {
@@ -119,6 +159,24 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 		log.Logger.Fatal("Cannot creating gateway server")
 	}
 }
+
+// incomingHeaderMatcher forwards the Idempotency-Key header to the gRPC
+// handlers on top of the headers kept by the default matcher.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, util.IdempotencyKey) {
+		return util.IdempotencyKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher passes Content-Disposition set by the handlers through
+// to the HTTP response, other metadata keeps the Grpc-Metadata- prefix.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "content-disposition") {
+		return "Content-Disposition", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
 func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store) {
 	mailer := pkg.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
 	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
@@ -128,3 +186,14 @@ func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store d
 		log.Logger.Fatal("error when start task processor")
 	}
 }
+func runTaskScheduler(redisOpt asynq.RedisClientOpt) {
+	scheduler, err := worker.NewTaskScheduler(redisOpt)
+	if err != nil {
+		log.Logger.Fatal("error when creating task scheduler", err)
+	}
+	log.Logger.Printf("start task scheduler")
+	err = scheduler.Start()
+	if err != nil {
+		log.Logger.Fatal("error when start task scheduler")
+	}
+}
@@ -0,0 +1,174 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.1
+// source: rpc_delete_scheduled_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DeleteScheduledTransferReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduledTransferReq) Reset() {
+	*x = DeleteScheduledTransferReq{}
+	mi := &file_rpc_delete_scheduled_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduledTransferReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduledTransferReq) ProtoMessage() {}
+
+func (x *DeleteScheduledTransferReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_delete_scheduled_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduledTransferReq.ProtoReflect.Descriptor instead.
+func (*DeleteScheduledTransferReq) Descriptor() ([]byte, []int) {
+	return file_rpc_delete_scheduled_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeleteScheduledTransferReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteScheduledTransferRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduledTransferRes) Reset() {
+	*x = DeleteScheduledTransferRes{}
+	mi := &file_rpc_delete_scheduled_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduledTransferRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduledTransferRes) ProtoMessage() {}
+
+func (x *DeleteScheduledTransferRes) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_delete_scheduled_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduledTransferRes.ProtoReflect.Descriptor instead.
+func (*DeleteScheduledTransferRes) Descriptor() ([]byte, []int) {
+	return file_rpc_delete_scheduled_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeleteScheduledTransferRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_rpc_delete_scheduled_transfer_proto protoreflect.FileDescriptor
+
+var file_rpc_delete_scheduled_transfer_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x72, 0x70, 0x63, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x22, 0x2c, 0x0a, 0x1a, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x34, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x09, 0x5a,
+	0x07, 0x6d, 0x61, 0x69, 0x6e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_delete_scheduled_transfer_proto_rawDescOnce sync.Once
+	file_rpc_delete_scheduled_transfer_proto_rawDescData = file_rpc_delete_scheduled_transfer_proto_rawDesc
+)
+
+func file_rpc_delete_scheduled_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_delete_scheduled_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_delete_scheduled_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_delete_scheduled_transfer_proto_rawDescData)
+	})
+	return file_rpc_delete_scheduled_transfer_proto_rawDescData
+}
+
+var file_rpc_delete_scheduled_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_delete_scheduled_transfer_proto_goTypes = []any{
+	(*DeleteScheduledTransferReq)(nil), // 0: pb.DeleteScheduledTransferReq
+	(*DeleteScheduledTransferRes)(nil), // 1: pb.DeleteScheduledTransferRes
+}
+var file_rpc_delete_scheduled_transfer_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_delete_scheduled_transfer_proto_init() }
+func file_rpc_delete_scheduled_transfer_proto_init() {
+	if File_rpc_delete_scheduled_transfer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_delete_scheduled_transfer_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_delete_scheduled_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_delete_scheduled_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_delete_scheduled_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_delete_scheduled_transfer_proto = out.File
+	file_rpc_delete_scheduled_transfer_proto_rawDesc = nil
+	file_rpc_delete_scheduled_transfer_proto_goTypes = nil
+	file_rpc_delete_scheduled_transfer_proto_depIdxs = nil
+}
@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.1
+// source: rpc_get_scheduled_transfer.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetScheduledTransferReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduledTransferReq) Reset() {
+	*x = GetScheduledTransferReq{}
+	mi := &file_rpc_get_scheduled_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduledTransferReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledTransferReq) ProtoMessage() {}
+
+func (x *GetScheduledTransferReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_scheduled_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledTransferReq.ProtoReflect.Descriptor instead.
+func (*GetScheduledTransferReq) Descriptor() ([]byte, []int) {
+	return file_rpc_get_scheduled_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetScheduledTransferReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetScheduledTransferRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data          *ScheduledTransfer     `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduledTransferRes) Reset() {
+	*x = GetScheduledTransferRes{}
+	mi := &file_rpc_get_scheduled_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduledTransferRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledTransferRes) ProtoMessage() {}
+
+func (x *GetScheduledTransferRes) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_scheduled_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledTransferRes.ProtoReflect.Descriptor instead.
+func (*GetScheduledTransferRes) Descriptor() ([]byte, []int) {
+	return file_rpc_get_scheduled_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetScheduledTransferRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetScheduledTransferRes) GetData() *ScheduledTransfer {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_rpc_get_scheduled_transfer_proto protoreflect.FileDescriptor
+
+var file_rpc_get_scheduled_transfer_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x18, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x29, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x5c, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x29,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70,
+	0x62, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x09, 0x5a, 0x07, 0x6d, 0x61, 0x69,
+	0x6e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_get_scheduled_transfer_proto_rawDescOnce sync.Once
+	file_rpc_get_scheduled_transfer_proto_rawDescData = file_rpc_get_scheduled_transfer_proto_rawDesc
+)
+
+func file_rpc_get_scheduled_transfer_proto_rawDescGZIP() []byte {
+	file_rpc_get_scheduled_transfer_proto_rawDescOnce.Do(func() {
+		file_rpc_get_scheduled_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_get_scheduled_transfer_proto_rawDescData)
+	})
+	return file_rpc_get_scheduled_transfer_proto_rawDescData
+}
+
+var file_rpc_get_scheduled_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_scheduled_transfer_proto_goTypes = []any{
+	(*GetScheduledTransferReq)(nil), // 0: pb.GetScheduledTransferReq
+	(*GetScheduledTransferRes)(nil), // 1: pb.GetScheduledTransferRes
+	(*ScheduledTransfer)(nil),       // 2: pb.ScheduledTransfer
+}
+var file_rpc_get_scheduled_transfer_proto_depIdxs = []int32{
+	2, // 0: pb.GetScheduledTransferRes.data:type_name -> pb.ScheduledTransfer
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_scheduled_transfer_proto_init() }
+func file_rpc_get_scheduled_transfer_proto_init() {
+	if File_rpc_get_scheduled_transfer_proto != nil {
+		return
+	}
+	file_scheduled_transfer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_get_scheduled_transfer_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_scheduled_transfer_proto_goTypes,
+		DependencyIndexes: file_rpc_get_scheduled_transfer_proto_depIdxs,
+		MessageInfos:      file_rpc_get_scheduled_transfer_proto_msgTypes,
+	}.Build()
+	File_rpc_get_scheduled_transfer_proto = out.File
+	file_rpc_get_scheduled_transfer_proto_rawDesc = nil
+	file_rpc_get_scheduled_transfer_proto_goTypes = nil
+	file_rpc_get_scheduled_transfer_proto_depIdxs = nil
+}
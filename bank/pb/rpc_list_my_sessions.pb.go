@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.1
+// source: rpc_list_my_sessions.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListMySessionsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMySessionsReq) Reset() {
+	*x = ListMySessionsReq{}
+	mi := &file_rpc_list_my_sessions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMySessionsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMySessionsReq) ProtoMessage() {}
+
+func (x *ListMySessionsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_my_sessions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMySessionsReq.ProtoReflect.Descriptor instead.
+func (*ListMySessionsReq) Descriptor() ([]byte, []int) {
+	return file_rpc_list_my_sessions_proto_rawDescGZIP(), []int{0}
+}
+
+type ListMySessionsRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data          []*Session             `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMySessionsRes) Reset() {
+	*x = ListMySessionsRes{}
+	mi := &file_rpc_list_my_sessions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMySessionsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMySessionsRes) ProtoMessage() {}
+
+func (x *ListMySessionsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_my_sessions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMySessionsRes.ProtoReflect.Descriptor instead.
+func (*ListMySessionsRes) Descriptor() ([]byte, []int) {
+	return file_rpc_list_my_sessions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListMySessionsRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListMySessionsRes) GetData() []*Session {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_rpc_list_my_sessions_proto protoreflect.FileDescriptor
+
+var file_rpc_list_my_sessions_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x6d, 0x79, 0x5f, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62,
+	0x1a, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x22, 0x4c, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x79, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1f, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x42, 0x09, 0x5a, 0x07, 0x6d, 0x61, 0x69, 0x6e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_my_sessions_proto_rawDescOnce sync.Once
+	file_rpc_list_my_sessions_proto_rawDescData = file_rpc_list_my_sessions_proto_rawDesc
+)
+
+func file_rpc_list_my_sessions_proto_rawDescGZIP() []byte {
+	file_rpc_list_my_sessions_proto_rawDescOnce.Do(func() {
+		file_rpc_list_my_sessions_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_my_sessions_proto_rawDescData)
+	})
+	return file_rpc_list_my_sessions_proto_rawDescData
+}
+
+var file_rpc_list_my_sessions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_my_sessions_proto_goTypes = []any{
+	(*ListMySessionsReq)(nil), // 0: pb.ListMySessionsReq
+	(*ListMySessionsRes)(nil), // 1: pb.ListMySessionsRes
+	(*Session)(nil),           // 2: pb.Session
+}
+var file_rpc_list_my_sessions_proto_depIdxs = []int32{
+	2, // 0: pb.ListMySessionsRes.data:type_name -> pb.Session
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_my_sessions_proto_init() }
+func file_rpc_list_my_sessions_proto_init() {
+	if File_rpc_list_my_sessions_proto != nil {
+		return
+	}
+	file_session_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_my_sessions_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_my_sessions_proto_goTypes,
+		DependencyIndexes: file_rpc_list_my_sessions_proto_depIdxs,
+		MessageInfos:      file_rpc_list_my_sessions_proto_msgTypes,
+	}.Build()
+	File_rpc_list_my_sessions_proto = out.File
+	file_rpc_list_my_sessions_proto_rawDesc = nil
+	file_rpc_list_my_sessions_proto_goTypes = nil
+	file_rpc_list_my_sessions_proto_depIdxs = nil
+}
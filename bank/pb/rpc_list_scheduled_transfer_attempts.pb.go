@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.1
+// source: rpc_list_scheduled_transfer_attempts.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListScheduledTransferAttemptsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Page          *int32                 `protobuf:"varint,2,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledTransferAttemptsReq) Reset() {
+	*x = ListScheduledTransferAttemptsReq{}
+	mi := &file_rpc_list_scheduled_transfer_attempts_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTransferAttemptsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTransferAttemptsReq) ProtoMessage() {}
+
+func (x *ListScheduledTransferAttemptsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_scheduled_transfer_attempts_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTransferAttemptsReq.ProtoReflect.Descriptor instead.
+func (*ListScheduledTransferAttemptsReq) Descriptor() ([]byte, []int) {
+	return file_rpc_list_scheduled_transfer_attempts_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListScheduledTransferAttemptsReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ListScheduledTransferAttemptsReq) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *ListScheduledTransferAttemptsReq) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListScheduledTransferAttemptsRes struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Status        string                      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data          []*ScheduledTransferAttempt `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledTransferAttemptsRes) Reset() {
+	*x = ListScheduledTransferAttemptsRes{}
+	mi := &file_rpc_list_scheduled_transfer_attempts_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTransferAttemptsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTransferAttemptsRes) ProtoMessage() {}
+
+func (x *ListScheduledTransferAttemptsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_scheduled_transfer_attempts_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTransferAttemptsRes.ProtoReflect.Descriptor instead.
+func (*ListScheduledTransferAttemptsRes) Descriptor() ([]byte, []int) {
+	return file_rpc_list_scheduled_transfer_attempts_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListScheduledTransferAttemptsRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListScheduledTransferAttemptsRes) GetData() []*ScheduledTransferAttempt {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_rpc_list_scheduled_transfer_attempts_proto protoreflect.FileDescriptor
+
+var file_rpc_list_scheduled_transfer_attempts_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62,
+	0x1a, 0x18, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x79, 0x0a, 0x20, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17,
+	0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x88,
+	0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x6c, 0x0a, 0x20, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x30, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x42, 0x09, 0x5a, 0x07, 0x6d, 0x61, 0x69, 0x6e, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_scheduled_transfer_attempts_proto_rawDescOnce sync.Once
+	file_rpc_list_scheduled_transfer_attempts_proto_rawDescData = file_rpc_list_scheduled_transfer_attempts_proto_rawDesc
+)
+
+func file_rpc_list_scheduled_transfer_attempts_proto_rawDescGZIP() []byte {
+	file_rpc_list_scheduled_transfer_attempts_proto_rawDescOnce.Do(func() {
+		file_rpc_list_scheduled_transfer_attempts_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_scheduled_transfer_attempts_proto_rawDescData)
+	})
+	return file_rpc_list_scheduled_transfer_attempts_proto_rawDescData
+}
+
+var file_rpc_list_scheduled_transfer_attempts_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_scheduled_transfer_attempts_proto_goTypes = []any{
+	(*ListScheduledTransferAttemptsReq)(nil), // 0: pb.ListScheduledTransferAttemptsReq
+	(*ListScheduledTransferAttemptsRes)(nil), // 1: pb.ListScheduledTransferAttemptsRes
+	(*ScheduledTransferAttempt)(nil),         // 2: pb.ScheduledTransferAttempt
+}
+var file_rpc_list_scheduled_transfer_attempts_proto_depIdxs = []int32{
+	2, // 0: pb.ListScheduledTransferAttemptsRes.data:type_name -> pb.ScheduledTransferAttempt
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_scheduled_transfer_attempts_proto_init() }
+func file_rpc_list_scheduled_transfer_attempts_proto_init() {
+	if File_rpc_list_scheduled_transfer_attempts_proto != nil {
+		return
+	}
+	file_scheduled_transfer_proto_init()
+	file_rpc_list_scheduled_transfer_attempts_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_scheduled_transfer_attempts_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_scheduled_transfer_attempts_proto_goTypes,
+		DependencyIndexes: file_rpc_list_scheduled_transfer_attempts_proto_depIdxs,
+		MessageInfos:      file_rpc_list_scheduled_transfer_attempts_proto_msgTypes,
+	}.Build()
+	File_rpc_list_scheduled_transfer_attempts_proto = out.File
+	file_rpc_list_scheduled_transfer_attempts_proto_rawDesc = nil
+	file_rpc_list_scheduled_transfer_attempts_proto_goTypes = nil
+	file_rpc_list_scheduled_transfer_attempts_proto_depIdxs = nil
+}
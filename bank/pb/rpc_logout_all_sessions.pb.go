@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.1
+// source: rpc_logout_all_sessions.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LogoutAllSessionsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllSessionsReq) Reset() {
+	*x = LogoutAllSessionsReq{}
+	mi := &file_rpc_logout_all_sessions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllSessionsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllSessionsReq) ProtoMessage() {}
+
+func (x *LogoutAllSessionsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_logout_all_sessions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllSessionsReq.ProtoReflect.Descriptor instead.
+func (*LogoutAllSessionsReq) Descriptor() ([]byte, []int) {
+	return file_rpc_logout_all_sessions_proto_rawDescGZIP(), []int{0}
+}
+
+type LogoutAllSessionsRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllSessionsRes) Reset() {
+	*x = LogoutAllSessionsRes{}
+	mi := &file_rpc_logout_all_sessions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllSessionsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllSessionsRes) ProtoMessage() {}
+
+func (x *LogoutAllSessionsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_logout_all_sessions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllSessionsRes.ProtoReflect.Descriptor instead.
+func (*LogoutAllSessionsRes) Descriptor() ([]byte, []int) {
+	return file_rpc_logout_all_sessions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LogoutAllSessionsRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_rpc_logout_all_sessions_proto protoreflect.FileDescriptor
+
+var file_rpc_logout_all_sessions_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x5f, 0x61, 0x6c, 0x6c,
+	0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x02, 0x70, 0x62, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x41, 0x6c, 0x6c,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x22, 0x2e, 0x0a, 0x14, 0x4c,
+	0x6f, 0x67, 0x6f, 0x75, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x09, 0x5a, 0x07, 0x6d,
+	0x61, 0x69, 0x6e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_logout_all_sessions_proto_rawDescOnce sync.Once
+	file_rpc_logout_all_sessions_proto_rawDescData = file_rpc_logout_all_sessions_proto_rawDesc
+)
+
+func file_rpc_logout_all_sessions_proto_rawDescGZIP() []byte {
+	file_rpc_logout_all_sessions_proto_rawDescOnce.Do(func() {
+		file_rpc_logout_all_sessions_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_logout_all_sessions_proto_rawDescData)
+	})
+	return file_rpc_logout_all_sessions_proto_rawDescData
+}
+
+var file_rpc_logout_all_sessions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_logout_all_sessions_proto_goTypes = []any{
+	(*LogoutAllSessionsReq)(nil), // 0: pb.LogoutAllSessionsReq
+	(*LogoutAllSessionsRes)(nil), // 1: pb.LogoutAllSessionsRes
+}
+var file_rpc_logout_all_sessions_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_logout_all_sessions_proto_init() }
+func file_rpc_logout_all_sessions_proto_init() {
+	if File_rpc_logout_all_sessions_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_logout_all_sessions_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_logout_all_sessions_proto_goTypes,
+		DependencyIndexes: file_rpc_logout_all_sessions_proto_depIdxs,
+		MessageInfos:      file_rpc_logout_all_sessions_proto_msgTypes,
+	}.Build()
+	File_rpc_logout_all_sessions_proto = out.File
+	file_rpc_logout_all_sessions_proto_rawDesc = nil
+	file_rpc_logout_all_sessions_proto_goTypes = nil
+	file_rpc_logout_all_sessions_proto_depIdxs = nil
+}
@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.1
+// source: service_admin.proto
+
+package pb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_service_admin_proto protoreflect.FileDescriptor
+
+var file_service_admin_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0f, 0x72, 0x70, 0x63, 0x5f, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0a, 0x61, 0x75, 0x74, 0x68,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x32, 0xad, 0x09, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5d, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x55,
+	0x73, 0x65, 0x72, 0x73, 0x12, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x22, 0x26, 0xa2, 0xbb,
+	0x18, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x11, 0x12, 0x0f, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x75,
+	0x73, 0x65, 0x72, 0x73, 0x12, 0x7f, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x22, 0x39, 0xa2, 0xbb, 0x18, 0x0b,
+	0x75, 0x73, 0x65, 0x72, 0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x24, 0x12, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x7f, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x22, 0x39, 0xa2, 0xbb, 0x18,
+	0x0b, 0x75, 0x73, 0x65, 0x72, 0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x24, 0x12, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x78, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a,
+	0x15, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x22, 0x38, 0xa2, 0xbb, 0x18, 0x0b, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x23, 0x3a, 0x01, 0x2a,
+	0x32, 0x1e, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x6f, 0x6c, 0x65,
+	0x12, 0x66, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0f, 0x2e, 0x70,
+	0x62, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e,
+	0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x22, 0x38,
+	0xa2, 0xbb, 0x18, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x23, 0x3a, 0x01, 0x2a, 0x22, 0x1e, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x7d, 0x2f, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x6e, 0x0a, 0x0a, 0x55, 0x6e, 0x6c, 0x6f,
+	0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x6e, 0x6c, 0x6f,
+	0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x55,
+	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x22, 0x3a, 0xa2, 0xbb,
+	0x18, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x25, 0x3a, 0x01, 0x2a, 0x22, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x7d, 0x2f, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x7d, 0x0a, 0x0d, 0x46, 0x72, 0x65, 0x65,
+	0x7a, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x46,
+	0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a,
+	0x14, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x22, 0x40, 0xa2, 0xbb, 0x18, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x73,
+	0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2b, 0x3a, 0x01, 0x2a, 0x22,
+	0x26, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x7d,
+	0x2f, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x12, 0x85, 0x01, 0x0a, 0x0f, 0x55, 0x6e, 0x66, 0x72,
+	0x65, 0x65, 0x7a, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x70, 0x62,
+	0x2e, 0x55, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a,
+	0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x22, 0x42, 0xa2, 0xbb, 0x18,
+	0x0b, 0x75, 0x73, 0x65, 0x72, 0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x2d, 0x3a, 0x01, 0x2a, 0x22, 0x28, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x75, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x12,
+	0x79, 0x0a, 0x0c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x13, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x22, 0x3f, 0xa2, 0xbb, 0x18, 0x0b, 0x75,
+	0x73, 0x65, 0x72, 0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2a,
+	0x3a, 0x01, 0x2a, 0x22, 0x25, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x68, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x62,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x22, 0x2b, 0xa2, 0xbb, 0x18, 0x0b, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x3a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14,
+	0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2d,
+	0x6c, 0x6f, 0x67, 0x73, 0x42, 0x09, 0x5a, 0x07, 0x6d, 0x61, 0x69, 0x6e, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_service_admin_proto_goTypes = []any{
+	(*SearchUsersReq)(nil),      // 0: pb.SearchUsersReq
+	(*ListUserAccountsReq)(nil), // 1: pb.ListUserAccountsReq
+	(*ListUserSessionsReq)(nil), // 2: pb.ListUserSessionsReq
+	(*UpdateUserRoleReq)(nil),   // 3: pb.UpdateUserRoleReq
+	(*LockUserReq)(nil),         // 4: pb.LockUserReq
+	(*UnlockUserReq)(nil),       // 5: pb.UnlockUserReq
+	(*FreezeAccountReq)(nil),    // 6: pb.FreezeAccountReq
+	(*UnfreezeAccountReq)(nil),  // 7: pb.UnfreezeAccountReq
+	(*BlockSessionReq)(nil),     // 8: pb.BlockSessionReq
+	(*ListAuditLogsReq)(nil),    // 9: pb.ListAuditLogsReq
+	(*SearchUsersRes)(nil),      // 10: pb.SearchUsersRes
+	(*ListUserAccountsRes)(nil), // 11: pb.ListUserAccountsRes
+	(*ListUserSessionsRes)(nil), // 12: pb.ListUserSessionsRes
+	(*UpdateUserRoleRes)(nil),   // 13: pb.UpdateUserRoleRes
+	(*LockUserRes)(nil),         // 14: pb.LockUserRes
+	(*UnlockUserRes)(nil),       // 15: pb.UnlockUserRes
+	(*FreezeAccountRes)(nil),    // 16: pb.FreezeAccountRes
+	(*UnfreezeAccountRes)(nil),  // 17: pb.UnfreezeAccountRes
+	(*BlockSessionRes)(nil),     // 18: pb.BlockSessionRes
+	(*ListAuditLogsRes)(nil),    // 19: pb.ListAuditLogsRes
+}
+var file_service_admin_proto_depIdxs = []int32{
+	0,  // 0: pb.AdminService.SearchUsers:input_type -> pb.SearchUsersReq
+	1,  // 1: pb.AdminService.ListUserAccounts:input_type -> pb.ListUserAccountsReq
+	2,  // 2: pb.AdminService.ListUserSessions:input_type -> pb.ListUserSessionsReq
+	3,  // 3: pb.AdminService.UpdateUserRole:input_type -> pb.UpdateUserRoleReq
+	4,  // 4: pb.AdminService.LockUser:input_type -> pb.LockUserReq
+	5,  // 5: pb.AdminService.UnlockUser:input_type -> pb.UnlockUserReq
+	6,  // 6: pb.AdminService.FreezeAccount:input_type -> pb.FreezeAccountReq
+	7,  // 7: pb.AdminService.UnfreezeAccount:input_type -> pb.UnfreezeAccountReq
+	8,  // 8: pb.AdminService.BlockSession:input_type -> pb.BlockSessionReq
+	9,  // 9: pb.AdminService.ListAuditLogs:input_type -> pb.ListAuditLogsReq
+	10, // 10: pb.AdminService.SearchUsers:output_type -> pb.SearchUsersRes
+	11, // 11: pb.AdminService.ListUserAccounts:output_type -> pb.ListUserAccountsRes
+	12, // 12: pb.AdminService.ListUserSessions:output_type -> pb.ListUserSessionsRes
+	13, // 13: pb.AdminService.UpdateUserRole:output_type -> pb.UpdateUserRoleRes
+	14, // 14: pb.AdminService.LockUser:output_type -> pb.LockUserRes
+	15, // 15: pb.AdminService.UnlockUser:output_type -> pb.UnlockUserRes
+	16, // 16: pb.AdminService.FreezeAccount:output_type -> pb.FreezeAccountRes
+	17, // 17: pb.AdminService.UnfreezeAccount:output_type -> pb.UnfreezeAccountRes
+	18, // 18: pb.AdminService.BlockSession:output_type -> pb.BlockSessionRes
+	19, // 19: pb.AdminService.ListAuditLogs:output_type -> pb.ListAuditLogsRes
+	10, // [10:20] is the sub-list for method output_type
+	0,  // [0:10] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_service_admin_proto_init() }
+func file_service_admin_proto_init() {
+	if File_service_admin_proto != nil {
+		return
+	}
+	file_rpc_admin_proto_init()
+	file_auth_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_service_admin_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_service_admin_proto_goTypes,
+		DependencyIndexes: file_service_admin_proto_depIdxs,
+	}.Build()
+	File_service_admin_proto = out.File
+	file_service_admin_proto_rawDesc = nil
+	file_service_admin_proto_goTypes = nil
+	file_service_admin_proto_depIdxs = nil
+}
@@ -8,6 +8,7 @@ package pb
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -19,9 +20,37 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SimpleBank_CreateUser_FullMethodName = "/pb.SimpleBank/CreateUser"
-	SimpleBank_UpdateMe_FullMethodName   = "/pb.SimpleBank/UpdateMe"
-	SimpleBank_LoginUser_FullMethodName  = "/pb.SimpleBank/LoginUser"
+	SimpleBank_CreateUser_FullMethodName                    = "/pb.SimpleBank/CreateUser"
+	SimpleBank_UpdateMe_FullMethodName                      = "/pb.SimpleBank/UpdateMe"
+	SimpleBank_VerifyEmail_FullMethodName                   = "/pb.SimpleBank/VerifyEmail"
+	SimpleBank_ResendVerifyEmail_FullMethodName             = "/pb.SimpleBank/ResendVerifyEmail"
+	SimpleBank_RequestPasswordReset_FullMethodName          = "/pb.SimpleBank/RequestPasswordReset"
+	SimpleBank_ResetPassword_FullMethodName                 = "/pb.SimpleBank/ResetPassword"
+	SimpleBank_LoginUser_FullMethodName                     = "/pb.SimpleBank/LoginUser"
+	SimpleBank_LoginUserMFA_FullMethodName                  = "/pb.SimpleBank/LoginUserMFA"
+	SimpleBank_SetupTOTP_FullMethodName                     = "/pb.SimpleBank/SetupTOTP"
+	SimpleBank_ConfirmTOTP_FullMethodName                   = "/pb.SimpleBank/ConfirmTOTP"
+	SimpleBank_RenewAccessToken_FullMethodName              = "/pb.SimpleBank/RenewAccessToken"
+	SimpleBank_ListMySessions_FullMethodName                = "/pb.SimpleBank/ListMySessions"
+	SimpleBank_RevokeSession_FullMethodName                 = "/pb.SimpleBank/RevokeSession"
+	SimpleBank_LogoutAllSessions_FullMethodName             = "/pb.SimpleBank/LogoutAllSessions"
+	SimpleBank_CreateApiKey_FullMethodName                  = "/pb.SimpleBank/CreateApiKey"
+	SimpleBank_ListApiKeys_FullMethodName                   = "/pb.SimpleBank/ListApiKeys"
+	SimpleBank_RevokeApiKey_FullMethodName                  = "/pb.SimpleBank/RevokeApiKey"
+	SimpleBank_CreateAccount_FullMethodName                 = "/pb.SimpleBank/CreateAccount"
+	SimpleBank_GetAccount_FullMethodName                    = "/pb.SimpleBank/GetAccount"
+	SimpleBank_ListAccounts_FullMethodName                  = "/pb.SimpleBank/ListAccounts"
+	SimpleBank_CloseAccount_FullMethodName                  = "/pb.SimpleBank/CloseAccount"
+	SimpleBank_ListAccountEntries_FullMethodName            = "/pb.SimpleBank/ListAccountEntries"
+	SimpleBank_GetAccountStatement_FullMethodName           = "/pb.SimpleBank/GetAccountStatement"
+	SimpleBank_EmailAccountStatement_FullMethodName         = "/pb.SimpleBank/EmailAccountStatement"
+	SimpleBank_TransferMoney_FullMethodName                 = "/pb.SimpleBank/TransferMoney"
+	SimpleBank_CreateScheduledTransfer_FullMethodName       = "/pb.SimpleBank/CreateScheduledTransfer"
+	SimpleBank_GetScheduledTransfer_FullMethodName          = "/pb.SimpleBank/GetScheduledTransfer"
+	SimpleBank_ListScheduledTransfers_FullMethodName        = "/pb.SimpleBank/ListScheduledTransfers"
+	SimpleBank_UpdateScheduledTransfer_FullMethodName       = "/pb.SimpleBank/UpdateScheduledTransfer"
+	SimpleBank_DeleteScheduledTransfer_FullMethodName       = "/pb.SimpleBank/DeleteScheduledTransfer"
+	SimpleBank_ListScheduledTransferAttempts_FullMethodName = "/pb.SimpleBank/ListScheduledTransferAttempts"
 )
 
 // SimpleBankClient is the client API for SimpleBank service.
@@ -30,7 +59,35 @@ const (
 type SimpleBankClient interface {
 	CreateUser(ctx context.Context, in *CreateUserReq, opts ...grpc.CallOption) (*CreateUserRes, error)
 	UpdateMe(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*UpdateUserRes, error)
+	VerifyEmail(ctx context.Context, in *VerifyEmailReq, opts ...grpc.CallOption) (*VerifyEmailRes, error)
+	ResendVerifyEmail(ctx context.Context, in *ResendVerifyEmailReq, opts ...grpc.CallOption) (*ResendVerifyEmailRes, error)
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetReq, opts ...grpc.CallOption) (*RequestPasswordResetRes, error)
+	ResetPassword(ctx context.Context, in *ResetPasswordReq, opts ...grpc.CallOption) (*ResetPasswordRes, error)
 	LoginUser(ctx context.Context, in *LoginUserReq, opts ...grpc.CallOption) (*LoginUserRes, error)
+	LoginUserMFA(ctx context.Context, in *LoginUserMFAReq, opts ...grpc.CallOption) (*LoginUserRes, error)
+	SetupTOTP(ctx context.Context, in *SetupTOTPReq, opts ...grpc.CallOption) (*SetupTOTPRes, error)
+	ConfirmTOTP(ctx context.Context, in *ConfirmTOTPReq, opts ...grpc.CallOption) (*ConfirmTOTPRes, error)
+	RenewAccessToken(ctx context.Context, in *RenewAccessTokenReq, opts ...grpc.CallOption) (*RenewAccessTokenRes, error)
+	ListMySessions(ctx context.Context, in *ListMySessionsReq, opts ...grpc.CallOption) (*ListMySessionsRes, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionReq, opts ...grpc.CallOption) (*RevokeSessionRes, error)
+	LogoutAllSessions(ctx context.Context, in *LogoutAllSessionsReq, opts ...grpc.CallOption) (*LogoutAllSessionsRes, error)
+	CreateApiKey(ctx context.Context, in *CreateApiKeyReq, opts ...grpc.CallOption) (*CreateApiKeyRes, error)
+	ListApiKeys(ctx context.Context, in *ListApiKeysReq, opts ...grpc.CallOption) (*ListApiKeysRes, error)
+	RevokeApiKey(ctx context.Context, in *RevokeApiKeyReq, opts ...grpc.CallOption) (*RevokeApiKeyRes, error)
+	CreateAccount(ctx context.Context, in *CreateAccountReq, opts ...grpc.CallOption) (*CreateAccountRes, error)
+	GetAccount(ctx context.Context, in *GetAccountReq, opts ...grpc.CallOption) (*GetAccountRes, error)
+	ListAccounts(ctx context.Context, in *ListAccountsReq, opts ...grpc.CallOption) (*ListAccountsRes, error)
+	CloseAccount(ctx context.Context, in *CloseAccountReq, opts ...grpc.CallOption) (*CloseAccountRes, error)
+	ListAccountEntries(ctx context.Context, in *ListAccountEntriesReq, opts ...grpc.CallOption) (*ListAccountEntriesRes, error)
+	GetAccountStatement(ctx context.Context, in *GetAccountStatementReq, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	EmailAccountStatement(ctx context.Context, in *EmailAccountStatementReq, opts ...grpc.CallOption) (*EmailAccountStatementRes, error)
+	TransferMoney(ctx context.Context, in *TransferMoneyReq, opts ...grpc.CallOption) (*TransferMoneyRes, error)
+	CreateScheduledTransfer(ctx context.Context, in *CreateScheduledTransferReq, opts ...grpc.CallOption) (*CreateScheduledTransferRes, error)
+	GetScheduledTransfer(ctx context.Context, in *GetScheduledTransferReq, opts ...grpc.CallOption) (*GetScheduledTransferRes, error)
+	ListScheduledTransfers(ctx context.Context, in *ListScheduledTransfersReq, opts ...grpc.CallOption) (*ListScheduledTransfersRes, error)
+	UpdateScheduledTransfer(ctx context.Context, in *UpdateScheduledTransferReq, opts ...grpc.CallOption) (*UpdateScheduledTransferRes, error)
+	DeleteScheduledTransfer(ctx context.Context, in *DeleteScheduledTransferReq, opts ...grpc.CallOption) (*DeleteScheduledTransferRes, error)
+	ListScheduledTransferAttempts(ctx context.Context, in *ListScheduledTransferAttemptsReq, opts ...grpc.CallOption) (*ListScheduledTransferAttemptsRes, error)
 }
 
 type simpleBankClient struct {
@@ -61,6 +118,46 @@ func (c *simpleBankClient) UpdateMe(ctx context.Context, in *UpdateUserReq, opts
 	return out, nil
 }
 
+func (c *simpleBankClient) VerifyEmail(ctx context.Context, in *VerifyEmailReq, opts ...grpc.CallOption) (*VerifyEmailRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmailRes)
+	err := c.cc.Invoke(ctx, SimpleBank_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ResendVerifyEmail(ctx context.Context, in *ResendVerifyEmailReq, opts ...grpc.CallOption) (*ResendVerifyEmailRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendVerifyEmailRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ResendVerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetReq, opts ...grpc.CallOption) (*RequestPasswordResetRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestPasswordResetRes)
+	err := c.cc.Invoke(ctx, SimpleBank_RequestPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ResetPassword(ctx context.Context, in *ResetPasswordReq, opts ...grpc.CallOption) (*ResetPasswordRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPasswordRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *simpleBankClient) LoginUser(ctx context.Context, in *LoginUserReq, opts ...grpc.CallOption) (*LoginUserRes, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LoginUserRes)
@@ -71,13 +168,281 @@ func (c *simpleBankClient) LoginUser(ctx context.Context, in *LoginUserReq, opts
 	return out, nil
 }
 
+func (c *simpleBankClient) LoginUserMFA(ctx context.Context, in *LoginUserMFAReq, opts ...grpc.CallOption) (*LoginUserRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginUserRes)
+	err := c.cc.Invoke(ctx, SimpleBank_LoginUserMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetupTOTP(ctx context.Context, in *SetupTOTPReq, opts ...grpc.CallOption) (*SetupTOTPRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetupTOTPRes)
+	err := c.cc.Invoke(ctx, SimpleBank_SetupTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ConfirmTOTP(ctx context.Context, in *ConfirmTOTPReq, opts ...grpc.CallOption) (*ConfirmTOTPRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTOTPRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ConfirmTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RenewAccessToken(ctx context.Context, in *RenewAccessTokenReq, opts ...grpc.CallOption) (*RenewAccessTokenRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenewAccessTokenRes)
+	err := c.cc.Invoke(ctx, SimpleBank_RenewAccessToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListMySessions(ctx context.Context, in *ListMySessionsReq, opts ...grpc.CallOption) (*ListMySessionsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMySessionsRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ListMySessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RevokeSession(ctx context.Context, in *RevokeSessionReq, opts ...grpc.CallOption) (*RevokeSessionRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeSessionRes)
+	err := c.cc.Invoke(ctx, SimpleBank_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) LogoutAllSessions(ctx context.Context, in *LogoutAllSessionsReq, opts ...grpc.CallOption) (*LogoutAllSessionsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutAllSessionsRes)
+	err := c.cc.Invoke(ctx, SimpleBank_LogoutAllSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateApiKey(ctx context.Context, in *CreateApiKeyReq, opts ...grpc.CallOption) (*CreateApiKeyRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateApiKeyRes)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateApiKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListApiKeys(ctx context.Context, in *ListApiKeysReq, opts ...grpc.CallOption) (*ListApiKeysRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListApiKeysRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ListApiKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RevokeApiKey(ctx context.Context, in *RevokeApiKeyReq, opts ...grpc.CallOption) (*RevokeApiKeyRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeApiKeyRes)
+	err := c.cc.Invoke(ctx, SimpleBank_RevokeApiKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateAccount(ctx context.Context, in *CreateAccountReq, opts ...grpc.CallOption) (*CreateAccountRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAccountRes)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) GetAccount(ctx context.Context, in *GetAccountReq, opts ...grpc.CallOption) (*GetAccountRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccountRes)
+	err := c.cc.Invoke(ctx, SimpleBank_GetAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListAccounts(ctx context.Context, in *ListAccountsReq, opts ...grpc.CallOption) (*ListAccountsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CloseAccount(ctx context.Context, in *CloseAccountReq, opts ...grpc.CallOption) (*CloseAccountRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseAccountRes)
+	err := c.cc.Invoke(ctx, SimpleBank_CloseAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListAccountEntries(ctx context.Context, in *ListAccountEntriesReq, opts ...grpc.CallOption) (*ListAccountEntriesRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountEntriesRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ListAccountEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) GetAccountStatement(ctx context.Context, in *GetAccountStatementReq, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, SimpleBank_GetAccountStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) EmailAccountStatement(ctx context.Context, in *EmailAccountStatementReq, opts ...grpc.CallOption) (*EmailAccountStatementRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmailAccountStatementRes)
+	err := c.cc.Invoke(ctx, SimpleBank_EmailAccountStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) TransferMoney(ctx context.Context, in *TransferMoneyReq, opts ...grpc.CallOption) (*TransferMoneyRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferMoneyRes)
+	err := c.cc.Invoke(ctx, SimpleBank_TransferMoney_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateScheduledTransfer(ctx context.Context, in *CreateScheduledTransferReq, opts ...grpc.CallOption) (*CreateScheduledTransferRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateScheduledTransferRes)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) GetScheduledTransfer(ctx context.Context, in *GetScheduledTransferReq, opts ...grpc.CallOption) (*GetScheduledTransferRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScheduledTransferRes)
+	err := c.cc.Invoke(ctx, SimpleBank_GetScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListScheduledTransfers(ctx context.Context, in *ListScheduledTransfersReq, opts ...grpc.CallOption) (*ListScheduledTransfersRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledTransfersRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ListScheduledTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) UpdateScheduledTransfer(ctx context.Context, in *UpdateScheduledTransferReq, opts ...grpc.CallOption) (*UpdateScheduledTransferRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateScheduledTransferRes)
+	err := c.cc.Invoke(ctx, SimpleBank_UpdateScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) DeleteScheduledTransfer(ctx context.Context, in *DeleteScheduledTransferReq, opts ...grpc.CallOption) (*DeleteScheduledTransferRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteScheduledTransferRes)
+	err := c.cc.Invoke(ctx, SimpleBank_DeleteScheduledTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListScheduledTransferAttempts(ctx context.Context, in *ListScheduledTransferAttemptsReq, opts ...grpc.CallOption) (*ListScheduledTransferAttemptsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledTransferAttemptsRes)
+	err := c.cc.Invoke(ctx, SimpleBank_ListScheduledTransferAttempts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SimpleBankServer is the server API for SimpleBank service.
 // All implementations must embed UnimplementedSimpleBankServer
 // for forward compatibility.
 type SimpleBankServer interface {
 	CreateUser(context.Context, *CreateUserReq) (*CreateUserRes, error)
 	UpdateMe(context.Context, *UpdateUserReq) (*UpdateUserRes, error)
+	VerifyEmail(context.Context, *VerifyEmailReq) (*VerifyEmailRes, error)
+	ResendVerifyEmail(context.Context, *ResendVerifyEmailReq) (*ResendVerifyEmailRes, error)
+	RequestPasswordReset(context.Context, *RequestPasswordResetReq) (*RequestPasswordResetRes, error)
+	ResetPassword(context.Context, *ResetPasswordReq) (*ResetPasswordRes, error)
 	LoginUser(context.Context, *LoginUserReq) (*LoginUserRes, error)
+	LoginUserMFA(context.Context, *LoginUserMFAReq) (*LoginUserRes, error)
+	SetupTOTP(context.Context, *SetupTOTPReq) (*SetupTOTPRes, error)
+	ConfirmTOTP(context.Context, *ConfirmTOTPReq) (*ConfirmTOTPRes, error)
+	RenewAccessToken(context.Context, *RenewAccessTokenReq) (*RenewAccessTokenRes, error)
+	ListMySessions(context.Context, *ListMySessionsReq) (*ListMySessionsRes, error)
+	RevokeSession(context.Context, *RevokeSessionReq) (*RevokeSessionRes, error)
+	LogoutAllSessions(context.Context, *LogoutAllSessionsReq) (*LogoutAllSessionsRes, error)
+	CreateApiKey(context.Context, *CreateApiKeyReq) (*CreateApiKeyRes, error)
+	ListApiKeys(context.Context, *ListApiKeysReq) (*ListApiKeysRes, error)
+	RevokeApiKey(context.Context, *RevokeApiKeyReq) (*RevokeApiKeyRes, error)
+	CreateAccount(context.Context, *CreateAccountReq) (*CreateAccountRes, error)
+	GetAccount(context.Context, *GetAccountReq) (*GetAccountRes, error)
+	ListAccounts(context.Context, *ListAccountsReq) (*ListAccountsRes, error)
+	CloseAccount(context.Context, *CloseAccountReq) (*CloseAccountRes, error)
+	ListAccountEntries(context.Context, *ListAccountEntriesReq) (*ListAccountEntriesRes, error)
+	GetAccountStatement(context.Context, *GetAccountStatementReq) (*httpbody.HttpBody, error)
+	EmailAccountStatement(context.Context, *EmailAccountStatementReq) (*EmailAccountStatementRes, error)
+	TransferMoney(context.Context, *TransferMoneyReq) (*TransferMoneyRes, error)
+	CreateScheduledTransfer(context.Context, *CreateScheduledTransferReq) (*CreateScheduledTransferRes, error)
+	GetScheduledTransfer(context.Context, *GetScheduledTransferReq) (*GetScheduledTransferRes, error)
+	ListScheduledTransfers(context.Context, *ListScheduledTransfersReq) (*ListScheduledTransfersRes, error)
+	UpdateScheduledTransfer(context.Context, *UpdateScheduledTransferReq) (*UpdateScheduledTransferRes, error)
+	DeleteScheduledTransfer(context.Context, *DeleteScheduledTransferReq) (*DeleteScheduledTransferRes, error)
+	ListScheduledTransferAttempts(context.Context, *ListScheduledTransferAttemptsReq) (*ListScheduledTransferAttemptsRes, error)
 	mustEmbedUnimplementedSimpleBankServer()
 }
 
@@ -94,9 +459,93 @@ func (UnimplementedSimpleBankServer) CreateUser(context.Context, *CreateUserReq)
 func (UnimplementedSimpleBankServer) UpdateMe(context.Context, *UpdateUserReq) (*UpdateUserRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateMe not implemented")
 }
+func (UnimplementedSimpleBankServer) VerifyEmail(context.Context, *VerifyEmailReq) (*VerifyEmailRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedSimpleBankServer) ResendVerifyEmail(context.Context, *ResendVerifyEmailReq) (*ResendVerifyEmailRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendVerifyEmail not implemented")
+}
+func (UnimplementedSimpleBankServer) RequestPasswordReset(context.Context, *RequestPasswordResetReq) (*RequestPasswordResetRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedSimpleBankServer) ResetPassword(context.Context, *ResetPasswordReq) (*ResetPasswordRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
 func (UnimplementedSimpleBankServer) LoginUser(context.Context, *LoginUserReq) (*LoginUserRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
 }
+func (UnimplementedSimpleBankServer) LoginUserMFA(context.Context, *LoginUserMFAReq) (*LoginUserRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginUserMFA not implemented")
+}
+func (UnimplementedSimpleBankServer) SetupTOTP(context.Context, *SetupTOTPReq) (*SetupTOTPRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetupTOTP not implemented")
+}
+func (UnimplementedSimpleBankServer) ConfirmTOTP(context.Context, *ConfirmTOTPReq) (*ConfirmTOTPRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (UnimplementedSimpleBankServer) RenewAccessToken(context.Context, *RenewAccessTokenReq) (*RenewAccessTokenRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewAccessToken not implemented")
+}
+func (UnimplementedSimpleBankServer) ListMySessions(context.Context, *ListMySessionsReq) (*ListMySessionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMySessions not implemented")
+}
+func (UnimplementedSimpleBankServer) RevokeSession(context.Context, *RevokeSessionReq) (*RevokeSessionRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedSimpleBankServer) LogoutAllSessions(context.Context, *LogoutAllSessionsReq) (*LogoutAllSessionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutAllSessions not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateApiKey(context.Context, *CreateApiKeyReq) (*CreateApiKeyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiKey not implemented")
+}
+func (UnimplementedSimpleBankServer) ListApiKeys(context.Context, *ListApiKeysReq) (*ListApiKeysRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiKeys not implemented")
+}
+func (UnimplementedSimpleBankServer) RevokeApiKey(context.Context, *RevokeApiKeyReq) (*RevokeApiKeyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeApiKey not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateAccount(context.Context, *CreateAccountReq) (*CreateAccountRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedSimpleBankServer) GetAccount(context.Context, *GetAccountReq) (*GetAccountRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedSimpleBankServer) ListAccounts(context.Context, *ListAccountsReq) (*ListAccountsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedSimpleBankServer) CloseAccount(context.Context, *CloseAccountReq) (*CloseAccountRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseAccount not implemented")
+}
+func (UnimplementedSimpleBankServer) ListAccountEntries(context.Context, *ListAccountEntriesReq) (*ListAccountEntriesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccountEntries not implemented")
+}
+func (UnimplementedSimpleBankServer) GetAccountStatement(context.Context, *GetAccountStatementReq) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountStatement not implemented")
+}
+func (UnimplementedSimpleBankServer) EmailAccountStatement(context.Context, *EmailAccountStatementReq) (*EmailAccountStatementRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmailAccountStatement not implemented")
+}
+func (UnimplementedSimpleBankServer) TransferMoney(context.Context, *TransferMoneyReq) (*TransferMoneyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferMoney not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateScheduledTransfer(context.Context, *CreateScheduledTransferReq) (*CreateScheduledTransferRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScheduledTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) GetScheduledTransfer(context.Context, *GetScheduledTransferReq) (*GetScheduledTransferRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) ListScheduledTransfers(context.Context, *ListScheduledTransfersReq) (*ListScheduledTransfersRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTransfers not implemented")
+}
+func (UnimplementedSimpleBankServer) UpdateScheduledTransfer(context.Context, *UpdateScheduledTransferReq) (*UpdateScheduledTransferRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateScheduledTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) DeleteScheduledTransfer(context.Context, *DeleteScheduledTransferReq) (*DeleteScheduledTransferRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteScheduledTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) ListScheduledTransferAttempts(context.Context, *ListScheduledTransferAttemptsReq) (*ListScheduledTransferAttemptsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTransferAttempts not implemented")
+}
 func (UnimplementedSimpleBankServer) mustEmbedUnimplementedSimpleBankServer() {}
 func (UnimplementedSimpleBankServer) testEmbeddedByValue()                    {}
 
@@ -154,42 +603,658 @@ func _SimpleBank_UpdateMe_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SimpleBank_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginUserReq)
+func _SimpleBank_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SimpleBankServer).LoginUser(ctx, in)
+		return srv.(SimpleBankServer).VerifyEmail(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SimpleBank_LoginUser_FullMethodName,
+		FullMethod: SimpleBank_VerifyEmail_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SimpleBankServer).LoginUser(ctx, req.(*LoginUserReq))
+		return srv.(SimpleBankServer).VerifyEmail(ctx, req.(*VerifyEmailReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// SimpleBank_ServiceDesc is the grpc.ServiceDesc for SimpleBank service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var SimpleBank_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.SimpleBank",
-	HandlerType: (*SimpleBankServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateUser",
-			Handler:    _SimpleBank_CreateUser_Handler,
-		},
-		{
-			MethodName: "UpdateMe",
-			Handler:    _SimpleBank_UpdateMe_Handler,
-		},
-		{
-			MethodName: "LoginUser",
-			Handler:    _SimpleBank_LoginUser_Handler,
+func _SimpleBank_ResendVerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendVerifyEmailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ResendVerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ResendVerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ResendVerifyEmail(ctx, req.(*ResendVerifyEmailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPasswordResetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RequestPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RequestPasswordReset(ctx, req.(*RequestPasswordResetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ResetPassword(ctx, req.(*ResetPasswordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).LoginUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_LoginUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).LoginUser(ctx, req.(*LoginUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_LoginUserMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginUserMFAReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).LoginUserMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_LoginUserMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).LoginUserMFA(ctx, req.(*LoginUserMFAReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetupTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetupTOTPReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetupTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetupTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetupTOTP(ctx, req.(*SetupTOTPReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ConfirmTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ConfirmTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ConfirmTOTP(ctx, req.(*ConfirmTOTPReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_RenewAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewAccessTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RenewAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RenewAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RenewAccessToken(ctx, req.(*RenewAccessTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListMySessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMySessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListMySessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListMySessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListMySessions(ctx, req.(*ListMySessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RevokeSession(ctx, req.(*RevokeSessionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_LogoutAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutAllSessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).LogoutAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_LogoutAllSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).LogoutAllSessions(ctx, req.(*LogoutAllSessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateApiKey(ctx, req.(*CreateApiKeyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiKeysReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListApiKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListApiKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListApiKeys(ctx, req.(*ListApiKeysReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_RevokeApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiKeyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RevokeApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RevokeApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RevokeApiKey(ctx, req.(*RevokeApiKeyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateAccount(ctx, req.(*CreateAccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).GetAccount(ctx, req.(*GetAccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListAccounts(ctx, req.(*ListAccountsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CloseAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseAccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CloseAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CloseAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CloseAccount(ctx, req.(*CloseAccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListAccountEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountEntriesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListAccountEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListAccountEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListAccountEntries(ctx, req.(*ListAccountEntriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_GetAccountStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStatementReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).GetAccountStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_GetAccountStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).GetAccountStatement(ctx, req.(*GetAccountStatementReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_EmailAccountStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailAccountStatementReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).EmailAccountStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_EmailAccountStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).EmailAccountStatement(ctx, req.(*EmailAccountStatementReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_TransferMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferMoneyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).TransferMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_TransferMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).TransferMoney(ctx, req.(*TransferMoneyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduledTransferReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateScheduledTransfer(ctx, req.(*CreateScheduledTransferReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_GetScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledTransferReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).GetScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_GetScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).GetScheduledTransfer(ctx, req.(*GetScheduledTransferReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListScheduledTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledTransfersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListScheduledTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListScheduledTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListScheduledTransfers(ctx, req.(*ListScheduledTransfersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_UpdateScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduledTransferReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).UpdateScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_UpdateScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).UpdateScheduledTransfer(ctx, req.(*UpdateScheduledTransferReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_DeleteScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduledTransferReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).DeleteScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_DeleteScheduledTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).DeleteScheduledTransfer(ctx, req.(*DeleteScheduledTransferReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListScheduledTransferAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledTransferAttemptsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListScheduledTransferAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListScheduledTransferAttempts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListScheduledTransferAttempts(ctx, req.(*ListScheduledTransferAttemptsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SimpleBank_ServiceDesc is the grpc.ServiceDesc for SimpleBank service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimpleBank_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.SimpleBank",
+	HandlerType: (*SimpleBankServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _SimpleBank_CreateUser_Handler,
+		},
+		{
+			MethodName: "UpdateMe",
+			Handler:    _SimpleBank_UpdateMe_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _SimpleBank_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "ResendVerifyEmail",
+			Handler:    _SimpleBank_ResendVerifyEmail_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _SimpleBank_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _SimpleBank_ResetPassword_Handler,
+		},
+		{
+			MethodName: "LoginUser",
+			Handler:    _SimpleBank_LoginUser_Handler,
+		},
+		{
+			MethodName: "LoginUserMFA",
+			Handler:    _SimpleBank_LoginUserMFA_Handler,
+		},
+		{
+			MethodName: "SetupTOTP",
+			Handler:    _SimpleBank_SetupTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _SimpleBank_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "RenewAccessToken",
+			Handler:    _SimpleBank_RenewAccessToken_Handler,
+		},
+		{
+			MethodName: "ListMySessions",
+			Handler:    _SimpleBank_ListMySessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _SimpleBank_RevokeSession_Handler,
+		},
+		{
+			MethodName: "LogoutAllSessions",
+			Handler:    _SimpleBank_LogoutAllSessions_Handler,
+		},
+		{
+			MethodName: "CreateApiKey",
+			Handler:    _SimpleBank_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "ListApiKeys",
+			Handler:    _SimpleBank_ListApiKeys_Handler,
+		},
+		{
+			MethodName: "RevokeApiKey",
+			Handler:    _SimpleBank_RevokeApiKey_Handler,
+		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _SimpleBank_CreateAccount_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _SimpleBank_GetAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _SimpleBank_ListAccounts_Handler,
+		},
+		{
+			MethodName: "CloseAccount",
+			Handler:    _SimpleBank_CloseAccount_Handler,
+		},
+		{
+			MethodName: "ListAccountEntries",
+			Handler:    _SimpleBank_ListAccountEntries_Handler,
+		},
+		{
+			MethodName: "GetAccountStatement",
+			Handler:    _SimpleBank_GetAccountStatement_Handler,
+		},
+		{
+			MethodName: "EmailAccountStatement",
+			Handler:    _SimpleBank_EmailAccountStatement_Handler,
+		},
+		{
+			MethodName: "TransferMoney",
+			Handler:    _SimpleBank_TransferMoney_Handler,
+		},
+		{
+			MethodName: "CreateScheduledTransfer",
+			Handler:    _SimpleBank_CreateScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "GetScheduledTransfer",
+			Handler:    _SimpleBank_GetScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ListScheduledTransfers",
+			Handler:    _SimpleBank_ListScheduledTransfers_Handler,
+		},
+		{
+			MethodName: "UpdateScheduledTransfer",
+			Handler:    _SimpleBank_UpdateScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "DeleteScheduledTransfer",
+			Handler:    _SimpleBank_DeleteScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ListScheduledTransferAttempts",
+			Handler:    _SimpleBank_ListScheduledTransferAttempts_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,117 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/pkg/authz"
+	"main/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewKey(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key.Key, Prefix))
+	require.True(t, strings.HasPrefix(key.Key, key.Prefix))
+	require.Len(t, key.Prefix, prefixLength)
+	require.Equal(t, util.HashSecretToken(key.Key), key.Hash)
+
+	other, err := NewKey()
+	require.NoError(t, err)
+	require.NotEqual(t, key.Key, other.Key)
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	key, err := NewKey()
+	require.NoError(t, err)
+	user := db.User{UserID: 1, Email: util.RandomEmail(), Role: db.UserRoleUser}
+	apiKey := db.ApiKey{
+		ID:          7,
+		UserID:      user.UserID,
+		KeyHash:     key.Hash,
+		Permissions: []string{authz.PermissionAccountsRead},
+		ExpiredAt:   time.Now().Add(time.Hour),
+		CreatedAt:   time.Now(),
+	}
+	store.EXPECT().GetApiKeyByHash(gomock.Any(), key.Hash).Return(apiKey, nil)
+	store.EXPECT().GetUser(gomock.Any(), user.UserID).Return(user, nil)
+
+	authenticator := NewAuthenticator(store)
+	payload, err := authenticator.Authenticate(context.Background(), key.Key)
+	require.NoError(t, err)
+	require.Equal(t, int(user.UserID), payload.UserID)
+	require.Equal(t, user.Email, payload.Email)
+	require.Equal(t, user.Role, payload.Role)
+	require.Equal(t, apiKey.ID, payload.APIKeyID)
+	require.True(t, payload.HasPermission(authz.PermissionAccountsRead))
+	require.False(t, payload.HasPermission(authz.PermissionTransfersCreate))
+}
+
+func TestAuthenticateRejects(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	valid := db.ApiKey{UserID: 1, KeyHash: key.Hash, ExpiredAt: time.Now().Add(time.Hour)}
+
+	revoked := valid
+	revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	expired := valid
+	expired.ExpiredAt = time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name    string
+		key     string
+		stubs   func(store *mockdb.MockStore)
+		wantErr error
+	}{
+		{
+			name:    "WrongPrefix",
+			key:     "abc_" + key.Key[len(Prefix):],
+			stubs:   func(store *mockdb.MockStore) {},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name: "NotFound",
+			key:  key.Key,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByHash(gomock.Any(), key.Hash).Return(db.ApiKey{}, sql.ErrNoRows)
+			},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name: "Revoked",
+			key:  key.Key,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByHash(gomock.Any(), key.Hash).Return(revoked, nil)
+			},
+			wantErr: ErrKeyRevoked,
+		},
+		{
+			name: "Expired",
+			key:  key.Key,
+			stubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetApiKeyByHash(gomock.Any(), key.Hash).Return(expired, nil)
+			},
+			wantErr: ErrKeyExpired,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.stubs(store)
+
+			_, err := NewAuthenticator(store).Authenticate(context.Background(), tc.key)
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/token"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL bounds how long a change to role_permissions takes to
+// reach a running process.
+const DefaultCacheTTL = 30 * time.Second
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Authorizer decides whether a caller holds the permission an RPC or route
+// requires. It's shared by the gRPC, gateway and gin servers, the grants of
+// every role are cached for ttl so requests don't all go to Postgres.
+type Authorizer struct {
+	store db.Store
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu        sync.Mutex
+	grants    map[db.UserRole]map[string]bool
+	fetchedAt time.Time
+}
+
+func NewAuthorizer(store db.Store, ttl time.Duration) *Authorizer {
+	return &Authorizer{
+		store: store,
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+// Authorize returns ErrPermissionDenied unless the role of the caller is
+// granted permission, an API key must also have been given it.
+func (authorizer *Authorizer) Authorize(ctx context.Context, payload *token.Payload, permission string) error {
+	grants, err := authorizer.roleGrants(ctx)
+	if err != nil {
+		return err
+	}
+	if !grants[payload.Role][permission] {
+		return fmt.Errorf("%w: role '%s' lacks %s", ErrPermissionDenied, payload.Role, permission)
+	}
+	if !payload.HasPermission(permission) {
+		return fmt.Errorf("%w: api key lacks %s", ErrPermissionDenied, permission)
+	}
+	return nil
+}
+
+func (authorizer *Authorizer) roleGrants(ctx context.Context) (map[db.UserRole]map[string]bool, error) {
+	now := authorizer.now()
+
+	authorizer.mu.Lock()
+	grants, fetchedAt := authorizer.grants, authorizer.fetchedAt
+	authorizer.mu.Unlock()
+	if grants != nil && now.Sub(fetchedAt) < authorizer.ttl {
+		return grants, nil
+	}
+
+	rolePermissions, err := authorizer.store.ListRolePermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+	grants = make(map[db.UserRole]map[string]bool)
+	for _, rolePermission := range rolePermissions {
+		if grants[rolePermission.Role] == nil {
+			grants[rolePermission.Role] = make(map[string]bool)
+		}
+		grants[rolePermission.Role][rolePermission.Permission] = true
+	}
+
+	authorizer.mu.Lock()
+	defer authorizer.mu.Unlock()
+	authorizer.grants = grants
+	authorizer.fetchedAt = now
+	return grants, nil
+}
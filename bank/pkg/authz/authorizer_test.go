@@ -0,0 +1,45 @@
+package authz
+
+import (
+	"context"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/token"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorizer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	rolePermissions := []db.RolePermission{
+		{Role: db.UserRoleUser, Permission: PermissionAccountsRead},
+		{Role: db.UserRoleUser, Permission: PermissionTransfersCreate},
+		{Role: db.UserRoleAdmin, Permission: PermissionUsersAdmin},
+	}
+	store.EXPECT().ListRolePermissions(gomock.Any()).Times(2).Return(rolePermissions, nil)
+
+	now := time.Now()
+	authorizer := NewAuthorizer(store, time.Minute)
+	authorizer.now = func() time.Time { return now }
+
+	user := &token.Payload{UserID: 1, Role: db.UserRoleUser}
+	require.NoError(t, authorizer.Authorize(context.Background(), user, PermissionAccountsRead))
+	// served from the cache
+	require.ErrorIs(t, authorizer.Authorize(context.Background(), user, PermissionUsersAdmin), ErrPermissionDenied)
+
+	apiKey := &token.Payload{UserID: 1, Role: db.UserRoleUser, APIKeyID: 1, Permissions: []string{PermissionAccountsRead, PermissionUsersAdmin}}
+	require.NoError(t, authorizer.Authorize(context.Background(), apiKey, PermissionAccountsRead))
+	// the key holds it but the role of its user doesn't
+	require.ErrorIs(t, authorizer.Authorize(context.Background(), apiKey, PermissionUsersAdmin), ErrPermissionDenied)
+	// the role holds it but the key doesn't
+	require.ErrorIs(t, authorizer.Authorize(context.Background(), apiKey, PermissionTransfersCreate), ErrPermissionDenied)
+
+	now = now.Add(time.Minute)
+	admin := &token.Payload{UserID: 2, Role: db.UserRoleAdmin}
+	require.NoError(t, authorizer.Authorize(context.Background(), admin, PermissionUsersAdmin))
+}
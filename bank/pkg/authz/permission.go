@@ -0,0 +1,38 @@
+package authz
+
+import "slices"
+
+// Permissions are "resource:action" strings, roles are granted them in the
+// role_permissions table and RPCs require them through their permission
+// option.
+const (
+	PermissionUsersRead               = "users:read"
+	PermissionUsersWrite              = "users:write"
+	PermissionUsersAdmin              = "users:admin"
+	PermissionSessionsManage          = "sessions:manage"
+	PermissionAccountsRead            = "accounts:read"
+	PermissionAccountsReadAny         = "accounts:read_any"
+	PermissionAccountsCreate          = "accounts:create"
+	PermissionAccountsClose           = "accounts:close"
+	PermissionTransfersCreate         = "transfers:create"
+	PermissionScheduledTransfersRead  = "scheduled_transfers:read"
+	PermissionScheduledTransfersWrite = "scheduled_transfers:write"
+)
+
+var Permissions = []string{
+	PermissionUsersRead,
+	PermissionUsersWrite,
+	PermissionUsersAdmin,
+	PermissionSessionsManage,
+	PermissionAccountsRead,
+	PermissionAccountsReadAny,
+	PermissionAccountsCreate,
+	PermissionAccountsClose,
+	PermissionTransfersCreate,
+	PermissionScheduledTransfersRead,
+	PermissionScheduledTransfersWrite,
+}
+
+func IsValidPermission(permission string) bool {
+	return slices.Contains(Permissions, permission)
+}
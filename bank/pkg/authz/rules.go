@@ -0,0 +1,77 @@
+package authz
+
+import (
+	"fmt"
+	"main/pb"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Rules holds the permission each RPC requires, read from the permission
+// option of the methods in the pb package. RPCs without the option are
+// public and aren't in the maps.
+type Rules struct {
+	// GRPC is keyed by full method, e.g. "/pb.SimpleBank/GetAccount"
+	GRPC map[string]string
+	// Gateway is keyed by the http rule of the method, e.g.
+	// "GET /v1/accounts/{id}"
+	Gateway map[string]string
+}
+
+func LoadRules() *Rules {
+	rules := &Rules{
+		GRPC:    make(map[string]string),
+		Gateway: make(map[string]string),
+	}
+	protoregistry.GlobalFiles.RangeFilesByPackage("pb", func(file protoreflect.FileDescriptor) bool {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			service := services.Get(i)
+			methods := service.Methods()
+			for j := 0; j < methods.Len(); j++ {
+				rules.add(service, methods.Get(j))
+			}
+		}
+		return true
+	})
+	return rules
+}
+
+func (rules *Rules) add(service protoreflect.ServiceDescriptor, method protoreflect.MethodDescriptor) {
+	permission, _ := proto.GetExtension(method.Options(), pb.E_Permission).(string)
+	if permission == "" {
+		return
+	}
+	rules.GRPC[fmt.Sprintf("/%s/%s", service.FullName(), method.Name())] = permission
+
+	httpRule, _ := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if httpRule == nil {
+		return
+	}
+	for _, binding := range append([]*annotations.HttpRule{httpRule}, httpRule.GetAdditionalBindings()...) {
+		if route, ok := httpRoute(binding); ok {
+			rules.Gateway[route] = permission
+		}
+	}
+}
+
+func httpRoute(rule *annotations.HttpRule) (string, bool) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "GET " + pattern.Get, true
+	case *annotations.HttpRule_Post:
+		return "POST " + pattern.Post, true
+	case *annotations.HttpRule_Put:
+		return "PUT " + pattern.Put, true
+	case *annotations.HttpRule_Patch:
+		return "PATCH " + pattern.Patch, true
+	case *annotations.HttpRule_Delete:
+		return "DELETE " + pattern.Delete, true
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind() + " " + pattern.Custom.GetPath(), true
+	}
+	return "", false
+}
@@ -0,0 +1,29 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRules(t *testing.T) {
+	rules := LoadRules()
+
+	require.Equal(t, PermissionAccountsRead, rules.GRPC["/pb.SimpleBank/GetAccount"])
+	require.Equal(t, PermissionTransfersCreate, rules.GRPC["/pb.SimpleBank/TransferMoney"])
+	require.Equal(t, PermissionUsersAdmin, rules.GRPC["/pb.SimpleBank/CreateApiKey"])
+	require.Equal(t, PermissionAccountsRead, rules.Gateway["GET /v1/accounts/{id}"])
+	require.Equal(t, PermissionAccountsClose, rules.Gateway["POST /v1/accounts/{id}/close"])
+	require.Equal(t, PermissionScheduledTransfersWrite, rules.Gateway["PATCH /v1/scheduled-transfers/{id}"])
+	require.Equal(t, PermissionUsersAdmin, rules.GRPC["/pb.AdminService/LockUser"])
+	require.Equal(t, PermissionUsersAdmin, rules.Gateway["POST /v1/admin/users/{user_id}/lock"])
+
+	// no permission option means public
+	require.NotContains(t, rules.GRPC, "/pb.SimpleBank/LoginUser")
+	require.NotContains(t, rules.Gateway, "POST /v1/login")
+
+	for method, permission := range rules.GRPC {
+		require.True(t, IsValidPermission(permission), "%s requires unknown permission %q", method, permission)
+	}
+	require.Len(t, rules.Gateway, len(rules.GRPC))
+}
@@ -0,0 +1,71 @@
+package exchange
+
+import (
+	"main/util"
+	"math/big"
+)
+
+// Conversion is the outcome of pricing an amount in another currency.
+type Conversion struct {
+	Rate            *big.Rat
+	ConvertedAmount int64
+}
+
+// RateString formats the rate the way it is recorded on the transfer.
+func (conversion Conversion) RateString() string {
+	return conversion.Rate.FloatString(RatePrecision)
+}
+
+// Convert prices amount, given in the minor unit of from, in the minor unit of
+// to. The rate is first cut to RatePrecision decimal places and the result is
+// rounded half to even, so the recorded rate always reproduces the converted
+// amount.
+func Convert(amount int64, from string, to string, rate *big.Rat) Conversion {
+	rate = quantize(rate, RatePrecision)
+
+	converted := new(big.Rat).SetInt64(amount)
+	converted.Mul(converted, rate)
+	converted.Mul(converted, pow10Rat(util.CurrencyMinorUnits(to)-util.CurrencyMinorUnits(from)))
+
+	return Conversion{
+		Rate:            rate,
+		ConvertedAmount: roundHalfEven(converted).Int64(),
+	}
+}
+
+func quantize(value *big.Rat, places int) *big.Rat {
+	scale := pow10Rat(places)
+	scaled := new(big.Rat).Mul(value, scale)
+	return new(big.Rat).Quo(new(big.Rat).SetInt(roundHalfEven(scaled)), scale)
+}
+
+func roundHalfEven(value *big.Rat) *big.Int {
+	quo, rem := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	twiceRem := new(big.Int).Abs(rem)
+	twiceRem.Lsh(twiceRem, 1)
+
+	cmp := twiceRem.Cmp(value.Denom())
+	if cmp > 0 || (cmp == 0 && quo.Bit(0) == 1) {
+		if value.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo
+}
+
+func pow10Rat(exp int) *big.Rat {
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exp))), nil)
+	if exp < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), pow)
+	}
+	return new(big.Rat).SetInt(pow)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,115 @@
+package exchange
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRateProvider(t *testing.T) {
+	provider, err := NewFileRateProvider("testdata/rates.json")
+	require.NoError(t, err)
+
+	rate, err := provider.GetRate(context.Background(), "USD", "USD")
+	require.NoError(t, err)
+	require.Equal(t, "1", rate.RatString())
+
+	rate, err = provider.GetRate(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, "0.9200000000", rate.FloatString(RatePrecision))
+
+	// cross rates go through the base currency
+	rate, err = provider.GetRate(context.Background(), "EUR", "CAD")
+	require.NoError(t, err)
+	require.Equal(t, big.NewRat(136, 92).String(), rate.String())
+
+	_, err = provider.GetRate(context.Background(), "USD", "GBP")
+	require.ErrorIs(t, err, ErrRateNotFound)
+}
+
+func TestStaticRateProviderRejectsInvalidRates(t *testing.T) {
+	_, err := NewStaticRateProvider("USD", map[string]string{"EUR": "abc"})
+	require.Error(t, err)
+
+	_, err = NewStaticRateProvider("USD", map[string]string{"EUR": "-1"})
+	require.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   int64
+		from     string
+		to       string
+		rate     *big.Rat
+		expected int64
+		rateStr  string
+	}{
+		{
+			name:     "SameMinorUnit",
+			amount:   1000,
+			from:     "USD",
+			to:       "EUR",
+			rate:     big.NewRat(92, 100),
+			expected: 920,
+			rateStr:  "0.9200000000",
+		},
+		{
+			name:     "ToZeroDecimalCurrency",
+			amount:   150,
+			from:     "USD",
+			to:       "VND",
+			rate:     big.NewRat(25000, 1),
+			expected: 37500,
+			rateStr:  "25000.0000000000",
+		},
+		{
+			name:     "FromZeroDecimalCurrency",
+			amount:   25000,
+			from:     "VND",
+			to:       "USD",
+			rate:     big.NewRat(1, 25000),
+			expected: 100,
+			rateStr:  "0.0000400000",
+		},
+		{
+			name:     "HalfRoundsDownToEven",
+			amount:   5,
+			from:     "USD",
+			to:       "EUR",
+			rate:     big.NewRat(1, 2),
+			expected: 2,
+			rateStr:  "0.5000000000",
+		},
+		{
+			name:     "HalfRoundsUpToEven",
+			amount:   3,
+			from:     "USD",
+			to:       "EUR",
+			rate:     big.NewRat(1, 2),
+			expected: 2,
+			rateStr:  "0.5000000000",
+		},
+		{
+			name:     "RateCutToPrecision",
+			amount:   300,
+			from:     "EUR",
+			to:       "USD",
+			rate:     big.NewRat(100, 92),
+			expected: 326,
+			rateStr:  "1.0869565217",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			conversion := Convert(tc.amount, tc.from, tc.to, tc.rate)
+			require.Equal(t, tc.expected, conversion.ConvertedAmount)
+			require.Equal(t, tc.rateStr, conversion.RateString())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"main/util"
+	"math/big"
+	"os"
+)
+
+// RatePrecision is the number of decimal places kept on a rate before it is
+// applied and recorded on a transfer.
+const RatePrecision = 10
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// ExchangeRateProvider returns how many units of the to currency one unit of
+// the from currency buys.
+type ExchangeRateProvider interface {
+	GetRate(ctx context.Context, from string, to string) (*big.Rat, error)
+}
+
+// StaticRateProvider serves rates from a fixed table expressed against a base
+// currency, cross rates are derived through the base.
+type StaticRateProvider struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+func NewStaticRateProvider(base string, rates map[string]string) (*StaticRateProvider, error) {
+	provider := &StaticRateProvider{
+		base:  base,
+		rates: map[string]*big.Rat{base: big.NewRat(1, 1)},
+	}
+	for currency, value := range rates {
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q for %s", value, currency)
+		}
+		provider.rates[currency] = rate
+	}
+	return provider, nil
+}
+
+func (provider *StaticRateProvider) GetRate(ctx context.Context, from string, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	fromRate, ok := provider.rates[from]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: %w", from, to, ErrRateNotFound)
+	}
+	toRate, ok := provider.rates[to]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: %w", from, to, ErrRateNotFound)
+	}
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
+
+type rateFile struct {
+	Base  string            `json:"base"`
+	Rates map[string]string `json:"rates"`
+}
+
+// NewFileRateProvider loads a static rate table from a JSON file of the form
+// {"base": "USD", "rates": {"EUR": "0.92"}}.
+func NewFileRateProvider(path string) (*StaticRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate file: %w", err)
+	}
+	var file rateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rate file: %w", err)
+	}
+	if file.Base == "" {
+		return nil, fmt.Errorf("rate file %s has no base currency", path)
+	}
+	return NewStaticRateProvider(file.Base, file.Rates)
+}
+
+// NewRateProvider builds the provider configured by EXCHANGE_RATE_FILE. Without
+// a file only same currency transfers can be priced.
+func NewRateProvider(config util.Config) (ExchangeRateProvider, error) {
+	if config.ExchangeRateFile == "" {
+		return NewStaticRateProvider(string(util.USD), nil)
+	}
+	return NewFileRateProvider(config.ExchangeRateFile)
+}
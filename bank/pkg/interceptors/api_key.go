@@ -0,0 +1,23 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	db "main/db/sqlc"
+	"main/pkg/apikey"
+	"main/token"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (authInterceptor *AuthInterceptor) verifyAPIKey(ctx context.Context, key string) (*token.Payload, error) {
+	payload, err := authInterceptor.apiKeys.Authenticate(ctx, key)
+	if err != nil {
+		if errors.Is(err, apikey.ErrInvalidKey) || errors.Is(err, apikey.ErrKeyRevoked) || errors.Is(err, apikey.ErrKeyExpired) || errors.Is(err, db.ErrUserLocked) {
+			return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return payload, nil
+}
@@ -2,7 +2,14 @@ package interceptors
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	db "main/db/sqlc"
+	"main/pkg/apikey"
+	"main/pkg/authz"
+	"main/pkg/policy"
+	"main/pkg/revocation"
 	"main/token"
 	"net/http"
 	"strings"
@@ -19,36 +26,43 @@ type contextKey string
 const (
 	AuthorizationHeaderKey  string     = "authorization"
 	AuthorizationType       string     = "bearer"
+	AuthorizationTypeAPIKey string     = "apikey"
 	AuthorizationPayloadKey contextKey = "authorization_payload"
 )
 
-func getgRPCRoutes() map[string][]string {
-	const simpleBankServicesPath = "/pb.SimpleBank/"
-	return map[string][]string{
-		simpleBankServicesPath + "UpdateMe": {"user"},
-	}
-}
-func getGatewayRoutes() map[string][]string {
-	return map[string][]string{
-		"PUT /v1/users": {"user"},
-	}
-}
-
 type AuthInterceptor struct {
-	tokenMaker      token.Maker
-	accessibleRoles map[string][]string
+	tokenMaker  token.Maker
+	store       db.Store
+	emailPolicy *policy.EmailVerification
+	apiKeys     *apikey.Authenticator
+	authorizer  *authz.Authorizer
+	// permissions maps the authenticated methods or routes to the
+	// permission they require, everything else is public
+	permissions     map[string]string
+	grpcPolicies    map[string]func(req any) policy.Action
+	gatewayPolicies map[string]func(r *http.Request) policy.Action
 }
 
-func NewGRPCInterceptor(tokenMaker token.Maker) *AuthInterceptor {
+func NewGRPCInterceptor(tokenMaker token.Maker, store db.Store, authorizer *authz.Authorizer) *AuthInterceptor {
 	return &AuthInterceptor{
-		tokenMaker:      tokenMaker,
-		accessibleRoles: getgRPCRoutes(),
+		tokenMaker:   tokenMaker,
+		store:        store,
+		emailPolicy:  policy.NewEmailVerification(store),
+		apiKeys:      apikey.NewAuthenticator(store),
+		authorizer:   authorizer,
+		permissions:  authz.LoadRules().GRPC,
+		grpcPolicies: getgRPCPolicies(),
 	}
 }
-func NewGatewayInterceptor(tokenMaker token.Maker) *AuthInterceptor {
+func NewGatewayInterceptor(tokenMaker token.Maker, store db.Store, authorizer *authz.Authorizer) *AuthInterceptor {
 	return &AuthInterceptor{
 		tokenMaker:      tokenMaker,
-		accessibleRoles: getGatewayRoutes(),
+		store:           store,
+		emailPolicy:     policy.NewEmailVerification(store),
+		apiKeys:         apikey.NewAuthenticator(store),
+		authorizer:      authorizer,
+		permissions:     authz.LoadRules().Gateway,
+		gatewayPolicies: getGatewayPolicies(),
 	}
 }
 func (authInterceptor *AuthInterceptor) AuthMiddleware(ctx context.Context, grpcMux *runtime.ServeMux) http.Handler {
@@ -58,10 +72,13 @@ func (authInterceptor *AuthInterceptor) AuthMiddleware(ctx context.Context, grpc
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
+		if err := authInterceptor.checkGatewayPolicy(r, payload); err != nil {
+			runtime.HTTPError(r.Context(), grpcMux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
 
 		if payload != nil {
-			ctx = context.WithValue(ctx, AuthorizationPayloadKey, payload)
-			r = r.WithContext(ctx)
+			r = r.WithContext(context.WithValue(r.Context(), AuthorizationPayloadKey, payload))
 		}
 
 		grpcMux.ServeHTTP(w, r)
@@ -80,6 +97,9 @@ func (authInterceptor *AuthInterceptor) AuthInterceptor() grpc.UnaryServerInterc
 		if err != nil {
 			return nil, err
 		}
+		if err := authInterceptor.checkGRPCPolicy(ctx, info.FullMethod, req, payload); err != nil {
+			return nil, err
+		}
 
 		if payload != nil {
 			ctx = context.WithValue(ctx, AuthorizationPayloadKey, payload)
@@ -96,7 +116,7 @@ func (authInterceptor *AuthInterceptor) AuthInterceptor() grpc.UnaryServerInterc
 //	}
 
 func (authInterceptor *AuthInterceptor) AuthorizeGRPC(ctx context.Context, fullMethod string) (*token.Payload, error) {
-	allowedRoles, exists := authInterceptor.accessibleRoles[fullMethod]
+	permission, exists := authInterceptor.permissions[fullMethod]
 	if !exists {
 		return nil, nil // Public endpoint
 	}
@@ -106,21 +126,56 @@ func (authInterceptor *AuthInterceptor) AuthorizeGRPC(ctx context.Context, fullM
 		return nil, status.Errorf(codes.InvalidArgument, "missing metadata")
 	}
 
-	return authInterceptor.verifyAuth(md["authorization"], allowedRoles)
+	return authInterceptor.verifyAuth(ctx, md["authorization"], permission)
 }
 
 func (authInterceptor *AuthInterceptor) AuthorizeGateway(r *http.Request) (*token.Payload, error) {
-	fullMethod := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
-	allowedRoles, exists := authInterceptor.accessibleRoles[fullMethod]
+	permission, exists := matchGatewayRoute(authInterceptor.permissions, r.Method, r.URL.Path)
 	if !exists {
 		return nil, nil // Public endpoint
 	}
 
 	authHeader := []string{r.Header.Get("Authorization")}
-	return authInterceptor.verifyAuth(authHeader, allowedRoles)
+	return authInterceptor.verifyAuth(r.Context(), authHeader, permission)
+}
+
+// matchGatewayRoute looks up the value registered for a request, treating
+// "{param}" segments in the registered routes as wildcards.
+func matchGatewayRoute[T any](routes map[string]T, method string, path string) (T, bool) {
+	if value, exists := routes[fmt.Sprintf("%s %s", method, path)]; exists {
+		return value, true
+	}
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	for route, value := range routes {
+		routeMethod, routePath, found := strings.Cut(route, " ")
+		if !found || routeMethod != method {
+			continue
+		}
+		routeSegments := strings.Split(strings.Trim(routePath, "/"), "/")
+		if len(routeSegments) != len(pathSegments) {
+			continue
+		}
+		matched := true
+		for i, segment := range routeSegments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				continue
+			}
+			if segment != pathSegments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return value, true
+		}
+	}
+	var zero T
+	return zero, false
 }
 
-func (authInterceptor *AuthInterceptor) verifyAuth(authHeader []string, allowedRoles []string) (*token.Payload, error) {
+// verifyAuth accepts either a bearer access token or an "ApiKey <key>"
+// header, then checks the caller holds the permission the method requires.
+func (authInterceptor *AuthInterceptor) verifyAuth(ctx context.Context, authHeader []string, permission string) (*token.Payload, error) {
 	if len(authHeader) < 1 {
 		return nil, status.Errorf(codes.Unauthenticated, "missing authorization header")
 	}
@@ -130,21 +185,52 @@ func (authInterceptor *AuthInterceptor) verifyAuth(authHeader []string, allowedR
 		return nil, status.Errorf(codes.Unauthenticated, "invalid token format")
 	}
 
-	if strings.ToLower(fields[0]) != AuthorizationType {
-		return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization type %v", AuthorizationType)
+	var payload *token.Payload
+	var err error
+	switch strings.ToLower(fields[0]) {
+	case AuthorizationType:
+		payload, err = authInterceptor.verifyAccessToken(ctx, fields[1])
+	case AuthorizationTypeAPIKey:
+		payload, err = authInterceptor.verifyAPIKey(ctx, fields[1])
+	default:
+		return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization type %v", fields[0])
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	token := fields[1]
-	payload, err := authInterceptor.tokenMaker.VerifyToken(token)
+	if err := authInterceptor.authorizer.Authorize(ctx, payload, permission); err != nil {
+		if errors.Is(err, authz.ErrPermissionDenied) {
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return payload, nil
+}
+
+func (authInterceptor *AuthInterceptor) verifyAccessToken(ctx context.Context, accessToken string) (*token.Payload, error) {
+	payload, err := authInterceptor.tokenMaker.VerifyToken(accessToken)
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "token verification failed")
 	}
+	if payload.Type != token.TokenTypeAccess {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", token.ErrWrongTokenType)
+	}
 
-	for _, role := range allowedRoles {
-		if role == string(payload.Role) {
-			return payload, nil
+	// the token stays valid until it expires, the session it was issued for
+	// tells whether the user has logged out since
+	row, err := authInterceptor.store.GetSession(ctx, payload.SessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "session not found")
 		}
+		return nil, status.Errorf(codes.Internal, "error when getting session %v", err)
 	}
-
-	return nil, status.Errorf(codes.PermissionDenied, "user role '%s' does not have access to this resource", payload.Role)
+	if row.Session.IsBlocked || row.Session.UserID != int64(payload.UserID) {
+		return nil, status.Errorf(codes.Unauthenticated, "session has been revoked")
+	}
+	if err := revocation.CheckPassword(payload, row.PasswordChangedAt); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	return payload, nil
 }
@@ -0,0 +1,32 @@
+package interceptors
+
+import (
+	"context"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/token"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyAccessTokenRejectsRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	tokenMaker, err := token.NewPasetoMaker(util.RandomStr(32))
+	require.NoError(t, err)
+	interceptor := NewGRPCInterceptor(tokenMaker, store, nil)
+
+	refreshToken, _, err := tokenMaker.CreateToken(token.TokenTypeRefresh, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Hour)
+	require.NoError(t, err)
+
+	// rejected before the session is looked up
+	_, err = interceptor.verifyAccessToken(context.Background(), refreshToken)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,66 @@
+package interceptors
+
+import (
+	"context"
+	"main/pb"
+	"main/pkg/policy"
+	"main/token"
+	"net/http"
+)
+
+// getgRPCPolicies maps the methods gated by the email verification policy to
+// the action a request performs, an empty action skips the check.
+func getgRPCPolicies() map[string]func(req any) policy.Action {
+	const simpleBankServicesPath = "/pb.SimpleBank/"
+	return map[string]func(req any) policy.Action{
+		simpleBankServicesPath + "TransferMoney": func(req any) policy.Action { return policy.ActionTransfer },
+		// scheduled transfers are run by the worker as real transfers
+		simpleBankServicesPath + "CreateScheduledTransfer": func(req any) policy.Action { return policy.ActionTransfer },
+		simpleBankServicesPath + "CreateAccount":           func(req any) policy.Action { return policy.ActionCreateAccount },
+		simpleBankServicesPath + "UpdateMe": func(req any) policy.Action {
+			if updateReq, ok := req.(*pb.UpdateUserReq); ok && updateReq.GetEmail() != "" {
+				return policy.ActionChangeEmail
+			}
+			return ""
+		},
+	}
+}
+func getGatewayPolicies() map[string]func(r *http.Request) policy.Action {
+	return map[string]func(r *http.Request) policy.Action{
+		"POST /v1/transfers":           func(r *http.Request) policy.Action { return policy.ActionTransfer },
+		"POST /v1/scheduled-transfers": func(r *http.Request) policy.Action { return policy.ActionTransfer },
+		"POST /v1/accounts":            func(r *http.Request) policy.Action { return policy.ActionCreateAccount },
+		"PUT /v1/users/update-me": func(r *http.Request) policy.Action {
+			if changesEmail, _ := policy.RequestChangesEmail(r); changesEmail {
+				return policy.ActionChangeEmail
+			}
+			return ""
+		},
+	}
+}
+
+func (authInterceptor *AuthInterceptor) checkGRPCPolicy(ctx context.Context, fullMethod string, req any, payload *token.Payload) error {
+	resolve, exists := authInterceptor.grpcPolicies[fullMethod]
+	if !exists || payload == nil {
+		return nil
+	}
+	return authInterceptor.checkPolicy(ctx, resolve(req), payload)
+}
+
+func (authInterceptor *AuthInterceptor) checkGatewayPolicy(r *http.Request, payload *token.Payload) error {
+	resolve, exists := authInterceptor.gatewayPolicies[r.Method+" "+r.URL.Path]
+	if !exists || payload == nil {
+		return nil
+	}
+	return authInterceptor.checkPolicy(r.Context(), resolve(r), payload)
+}
+
+func (authInterceptor *AuthInterceptor) checkPolicy(ctx context.Context, action policy.Action, payload *token.Payload) error {
+	if action == "" {
+		return nil
+	}
+	if err := authInterceptor.emailPolicy.Check(ctx, int64(payload.UserID), action); err != nil {
+		return policy.GRPCError(action, err)
+	}
+	return nil
+}
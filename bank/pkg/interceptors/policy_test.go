@@ -0,0 +1,46 @@
+package interceptors
+
+import (
+	"context"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/pb"
+	"main/token"
+	"main/util"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func requireEmailNotVerified(t *testing.T, err error) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.PermissionDenied, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, util.ErrorEmailNotVerified, info.Reason)
+}
+
+func TestCreateScheduledTransferPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	user := db.User{UserID: 1, IsEmailVerified: false}
+	store.EXPECT().GetUser(gomock.Any(), user.UserID).Times(2).Return(user, nil)
+	payload := &token.Payload{UserID: int(user.UserID)}
+
+	grpcInterceptor := NewGRPCInterceptor(nil, store, nil)
+	err := grpcInterceptor.checkGRPCPolicy(context.Background(), "/pb.SimpleBank/CreateScheduledTransfer", &pb.CreateScheduledTransferReq{}, payload)
+	requireEmailNotVerified(t, err)
+
+	gatewayInterceptor := NewGatewayInterceptor(nil, store, nil)
+	r, err := http.NewRequest(http.MethodPost, "/v1/scheduled-transfers", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	requireEmailNotVerified(t, gatewayInterceptor.checkGatewayPolicy(r, payload))
+}
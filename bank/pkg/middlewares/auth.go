@@ -1,8 +1,13 @@
 package middlewares
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	db "main/db/sqlc"
+	"main/pkg/apikey"
+	"main/pkg/authz"
+	"main/pkg/revocation"
 	"main/token"
 	"net/http"
 	"strings"
@@ -13,10 +18,12 @@ import (
 const (
 	AuthorizationHeaderKey  string = "authorization"
 	AuthorizationType       string = "bearer"
+	AuthorizationTypeAPIKey string = "apikey"
 	AuthorizationPayloadKey string = "authorization_payload"
 )
 
-func AuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func AuthMiddleware(tokenMaker token.Maker, store db.Store) gin.HandlerFunc {
+	apiKeys := apikey.NewAuthenticator(store)
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(AuthorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -33,22 +40,77 @@ func AuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
+		if strings.ToLower(fields[0]) == AuthorizationTypeAPIKey {
+			payload, err := apiKeys.Authenticate(ctx, fields[1])
+			if err != nil {
+				if errors.Is(err, apikey.ErrInvalidKey) || errors.Is(err, apikey.ErrKeyRevoked) || errors.Is(err, apikey.ErrKeyExpired) || errors.Is(err, db.ErrUserLocked) {
+					ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+					return
+				}
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			ctx.Set(AuthorizationPayloadKey, payload)
+			ctx.Next()
+			return
+		}
 		if strings.ToLower(fields[0]) != AuthorizationType {
-			err := fmt.Errorf("unsupported authorization type %v", AuthorizationType)
+			err := fmt.Errorf("unsupported authorization type %v", fields[0])
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
-		token := fields[1]
+		accessToken := fields[1]
 
-		payload, err := tokenMaker.VerifyToken(token)
+		payload, err := tokenMaker.VerifyToken(accessToken)
 
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
+		if payload.Type != token.TokenTypeAccess {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": token.ErrWrongTokenType.Error()})
+			return
+		}
+
+		row, err := store.GetSession(ctx, payload.SessionID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				err = errors.New("session not found")
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if row.Session.IsBlocked || row.Session.UserID != int64(payload.UserID) {
+			err := errors.New("session has been revoked")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		if err := revocation.CheckPassword(payload, row.PasswordChangedAt); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
 
 		ctx.Set(AuthorizationPayloadKey, payload)
 		ctx.Next()
 	}
 
 }
+
+// RequirePermission goes after AuthMiddleware on every private route, the
+// caller must hold permission through its role and, for an API key, the key.
+func RequirePermission(authorizer *authz.Authorizer, permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+		if err := authorizer.Authorize(ctx, payload, permission); err != nil {
+			if errors.Is(err, authz.ErrPermissionDenied) {
+				ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Next()
+	}
+}
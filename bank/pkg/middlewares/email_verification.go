@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"errors"
+	"main/pkg/policy"
+	"main/token"
+	"main/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailVerificationMiddleware applies the email verification policy to a
+// route, it has to run after AuthMiddleware. A change email action is only
+// checked when the request body sets an email.
+func EmailVerificationMiddleware(emailPolicy *policy.EmailVerification, action policy.Action) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+		if action == policy.ActionChangeEmail {
+			changesEmail, err := policy.RequestChangesEmail(ctx.Request)
+			if err != nil {
+				ctx.Error(util.NewBadRequestError(err, err.Error()))
+				ctx.Abort()
+				return
+			}
+			if !changesEmail {
+				ctx.Next()
+				return
+			}
+		}
+
+		err := emailPolicy.Check(ctx, int64(payload.UserID), action)
+		if err != nil {
+			if errors.Is(err, policy.ErrEmailNotVerified) {
+				ctx.Error(util.NewEmailNotVerifiedError(err, err.Error()))
+			} else {
+				ctx.Error(util.NewInternalServerError(err, err.Error()))
+			}
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,146 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/util"
+	"sync"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrMissingIDToken   = errors.New("token response has no id_token")
+	ErrNonceMismatch    = errors.New("id token nonce doesn't match the login")
+	ErrEmailNotVerified = errors.New("identity provider hasn't verified the email")
+)
+
+// Identity is the user an identity provider signed in.
+type Identity struct {
+	Issuer   string
+	Subject  string
+	Email    string
+	FullName string
+}
+
+// AuthRequest is a login sent to the identity provider, State, Nonce and
+// CodeVerifier have to be kept until its callback.
+type AuthRequest struct {
+	URL          string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// Client signs users in with the authorization code flow and PKCE. The
+// provider is discovered from the issuer on first use, so the server starts
+// while it's unreachable.
+type Client struct {
+	issuerURL    string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+
+	mu       sync.Mutex
+	provider *gooidc.Provider
+}
+
+func NewClient(config util.Config) *Client {
+	return &Client{
+		issuerURL:    config.OIDCIssuerURL,
+		clientID:     config.OIDCClientID,
+		clientSecret: config.OIDCClientSecret,
+		redirectURL:  config.OIDCRedirectURL,
+	}
+}
+
+// NewAuthRequest starts a login, the user has to be sent to its URL.
+func (client *Client) NewAuthRequest(ctx context.Context) (*AuthRequest, error) {
+	oauth2Config, _, err := client.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state, err := util.NewSecretToken(32)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := util.NewSecretToken(32)
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier := oauth2.GenerateVerifier()
+
+	return &AuthRequest{
+		URL:          oauth2Config.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)),
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+	}, nil
+}
+
+// Exchange trades the code of the callback for an id token and returns the
+// identity it asserts, once its signature, issuer, audience, expiry and
+// nonce are checked.
+func (client *Client) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	oauth2Config, verifier, err := client.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("cannot exchange code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, ErrMissingIDToken
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("cannot read id token claims: %w", err)
+	}
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+	return &Identity{
+		Issuer:   idToken.Issuer,
+		Subject:  idToken.Subject,
+		Email:    claims.Email,
+		FullName: claims.Name,
+	}, nil
+}
+
+func (client *Client) config(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.provider == nil {
+		provider, err := gooidc.NewProvider(ctx, client.issuerURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot discover identity provider: %w", err)
+		}
+		client.provider = provider
+	}
+
+	oauth2Config := &oauth2.Config{
+		ClientID:     client.clientID,
+		ClientSecret: client.clientSecret,
+		RedirectURL:  client.redirectURL,
+		Endpoint:     client.provider.Endpoint(),
+		Scopes:       []string{gooidc.ScopeOpenID, "email", "profile"},
+	}
+	verifier := client.provider.Verifier(&gooidc.Config{ClientID: client.clientID})
+	return oauth2Config, verifier, nil
+}
@@ -0,0 +1,68 @@
+package oidc
+
+import (
+	"context"
+	"main/pkg/oidc/oidctest"
+	"main/util"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(provider *oidctest.Provider) *Client {
+	return NewClient(util.Config{
+		OIDCIssuerURL:    provider.Issuer(),
+		OIDCClientID:     "simple-bank",
+		OIDCClientSecret: util.RandomStr(16),
+		OIDCRedirectURL:  "http://localhost:8080/v1/auth/oidc/callback",
+	})
+}
+
+func TestClientExchange(t *testing.T) {
+	provider := oidctest.NewProvider(t)
+	client := newTestClient(provider)
+
+	authRequest, err := client.NewAuthRequest(context.Background())
+	require.NoError(t, err)
+	email := util.RandomEmail()
+	code := provider.Authorize(authRequest.URL, jwt.MapClaims{
+		"sub":            "user-1",
+		"email":          email,
+		"email_verified": true,
+		"name":           "Test User",
+	})
+
+	identity, err := client.Exchange(context.Background(), code, authRequest.CodeVerifier, authRequest.Nonce)
+	require.NoError(t, err)
+	require.Equal(t, provider.Issuer(), identity.Issuer)
+	require.Equal(t, "user-1", identity.Subject)
+	require.Equal(t, email, identity.Email)
+	require.Equal(t, "Test User", identity.FullName)
+}
+
+func TestClientExchangeRejects(t *testing.T) {
+	provider := oidctest.NewProvider(t)
+	client := newTestClient(provider)
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{"sub": "user-1", "email": util.RandomEmail(), "email_verified": true}
+	}
+
+	authRequest, err := client.NewAuthRequest(context.Background())
+	require.NoError(t, err)
+
+	// PKCE: the code is useless without the verifier of the login
+	code := provider.Authorize(authRequest.URL, claims())
+	_, err = client.Exchange(context.Background(), code, "wrong-verifier", authRequest.Nonce)
+	require.Error(t, err)
+
+	code = provider.Authorize(authRequest.URL, claims())
+	_, err = client.Exchange(context.Background(), code, authRequest.CodeVerifier, "other-nonce")
+	require.ErrorIs(t, err, ErrNonceMismatch)
+
+	unverified := claims()
+	unverified["email_verified"] = false
+	code = provider.Authorize(authRequest.URL, unverified)
+	_, err = client.Exchange(context.Background(), code, authRequest.CodeVerifier, authRequest.Nonce)
+	require.ErrorIs(t, err, ErrEmailNotVerified)
+}
@@ -0,0 +1,128 @@
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"main/util"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+// Provider is an in-process OIDC provider that signs in whoever the test
+// authorizes, with the authorization code flow and PKCE.
+type Provider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]login
+}
+
+type login struct {
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+// NewProvider starts a provider that is closed with the test.
+func NewProvider(t *testing.T) *Provider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	provider := &Provider{t: t, key: key, codes: make(map[string]login)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("/jwks", provider.jwks)
+	mux.HandleFunc("/token", provider.token)
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+	return provider
+}
+
+// Issuer is the URL the provider is discovered from.
+func (provider *Provider) Issuer() string {
+	return provider.server.URL
+}
+
+func (provider *Provider) discovery(res http.ResponseWriter, req *http.Request) {
+	json.NewEncoder(res).Encode(map[string]interface{}{
+		"issuer":                                provider.Issuer(),
+		"authorization_endpoint":                provider.Issuer() + "/authorize",
+		"token_endpoint":                        provider.Issuer() + "/token",
+		"jwks_uri":                              provider.Issuer() + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (provider *Provider) jwks(res http.ResponseWriter, req *http.Request) {
+	json.NewEncoder(res).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "fake",
+			"n":   base64.RawURLEncoding.EncodeToString(provider.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(provider.key.E)).Bytes()),
+		}},
+	})
+}
+
+// Authorize plays the user signing in at the authorization URL with the
+// claims of their id token, and returns the code the provider redirects back
+// with.
+func (provider *Provider) Authorize(authURL string, claims jwt.MapClaims) string {
+	parsed, err := url.Parse(authURL)
+	require.NoError(provider.t, err)
+	query := parsed.Query()
+	require.Equal(provider.t, "S256", query.Get("code_challenge_method"))
+
+	claims["iss"] = provider.Issuer()
+	claims["aud"] = query.Get("client_id")
+	claims["nonce"] = query.Get("nonce")
+	claims["iat"] = time.Now().Unix()
+	claims["exp"] = time.Now().Add(time.Minute).Unix()
+
+	code := util.RandomStr(16)
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	provider.codes[code] = login{codeChallenge: query.Get("code_challenge"), claims: claims}
+	return code
+}
+
+func (provider *Provider) token(res http.ResponseWriter, req *http.Request) {
+	provider.mu.Lock()
+	login, ok := provider.codes[req.FormValue("code")]
+	delete(provider.codes, req.FormValue("code"))
+	provider.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(req.FormValue("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != login.codeChallenge {
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, login.claims)
+	idToken.Header["kid"] = "fake"
+	signed, err := idToken.SignedString(provider.key)
+	require.NoError(provider.t, err)
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(map[string]interface{}{
+		"access_token": util.RandomStr(16),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
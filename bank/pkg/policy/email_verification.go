@@ -0,0 +1,109 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	db "main/db/sqlc"
+	"main/util"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is sent in the ErrorInfo details of gRPC errors raised by a
+// policy.
+const ErrorDomain = "simplebank"
+
+var ErrEmailNotVerified = errors.New("email address must be verified first")
+
+type Action string
+
+const (
+	ActionTransfer      Action = "transfer"
+	ActionCreateAccount Action = "create_account"
+	ActionChangeEmail   Action = "change_email"
+)
+
+// EmailVerification blocks sensitive actions until the user has verified
+// their email address. A user may still open a first account before that.
+type EmailVerification struct {
+	store db.Store
+}
+
+func NewEmailVerification(store db.Store) *EmailVerification {
+	return &EmailVerification{store: store}
+}
+
+// Check returns ErrEmailNotVerified when the user isn't allowed to perform
+// the action yet.
+func (policy *EmailVerification) Check(ctx context.Context, userID int64, action Action) error {
+	user, err := policy.store.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsEmailVerified {
+		return nil
+	}
+
+	switch action {
+	case ActionCreateAccount:
+		count, err := policy.store.CountAccounts(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to count accounts: %w", err)
+		}
+		if count == 0 {
+			return nil
+		}
+	case ActionTransfer, ActionChangeEmail:
+	default:
+		return nil
+	}
+	return ErrEmailNotVerified
+}
+
+// GRPCError converts an error returned by Check into a status error, the
+// ErrorInfo reason carries util.ErrorEmailNotVerified for the clients.
+func GRPCError(action Action, err error) error {
+	if !errors.Is(err, ErrEmailNotVerified) {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	statusDenied := status.New(codes.PermissionDenied, err.Error())
+	statusDetails, detailsErr := statusDenied.WithDetails(&errdetails.ErrorInfo{
+		Reason:   util.ErrorEmailNotVerified,
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{"action": string(action)},
+	})
+	if detailsErr != nil {
+		return statusDenied.Err()
+	}
+	return statusDetails.Err()
+}
+
+// RequestChangesEmail reports whether a JSON request body sets a new email.
+// The body is put back so the handler can still read it.
+func RequestChangesEmail(r *http.Request) (bool, error) {
+	if r.Body == nil {
+		return false, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return false, err
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		// malformed bodies are rejected by the handler itself
+		return false, nil
+	}
+	return req.Email != "", nil
+}
@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestChangesEmail(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{name: "Email", body: `{"email":"new@example.com"}`, want: true},
+		{name: "EmptyEmail", body: `{"email":"","fullname":"John"}`, want: false},
+		{name: "NoEmail", body: `{"fullname":"John"}`, want: false},
+		{name: "Malformed", body: `{"email":`, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodPut, "/v1/users/update-me", strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			changesEmail, err := RequestChangesEmail(r)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, changesEmail)
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.body, string(body))
+		})
+	}
+}
+
+func TestGRPCError(t *testing.T) {
+	st, ok := status.FromError(GRPCError(ActionTransfer, ErrEmailNotVerified))
+	require.True(t, ok)
+	require.Equal(t, codes.PermissionDenied, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, "ERROR_07", info.Reason)
+	require.Equal(t, string(ActionTransfer), info.Metadata["action"])
+}
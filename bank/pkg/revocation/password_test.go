@@ -0,0 +1,19 @@
+package revocation
+
+import (
+	"main/token"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPassword(t *testing.T) {
+	changedAt := time.Now().Add(-time.Hour)
+
+	oldToken := &token.Payload{UserID: 1, IssuedAt: changedAt.Add(-time.Minute)}
+	newToken := &token.Payload{UserID: 1, IssuedAt: changedAt.Add(time.Minute)}
+
+	require.ErrorIs(t, CheckPassword(oldToken, changedAt), ErrTokenRevoked)
+	require.NoError(t, CheckPassword(newToken, changedAt))
+}
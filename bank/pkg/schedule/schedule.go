@@ -0,0 +1,57 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MinInterval is the shortest interval accepted, due schedules are only picked
+// up once a minute.
+const MinInterval = time.Minute
+
+var (
+	ErrInvalidSchedule = errors.New("exactly one of cron_expression and interval_seconds must be set")
+	parser             = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+)
+
+// Schedule is either a standard 5 field cron expression evaluated in UTC or a
+// fixed interval.
+type Schedule struct {
+	cron     cron.Schedule
+	interval time.Duration
+}
+
+func Parse(cronExpression string, intervalSeconds int64) (Schedule, error) {
+	if (cronExpression == "") == (intervalSeconds == 0) {
+		return Schedule{}, ErrInvalidSchedule
+	}
+	if cronExpression != "" {
+		parsed, err := parser.Parse(cronExpression)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid cron expression: %w", err)
+		}
+		return Schedule{cron: parsed}, nil
+	}
+	interval := time.Duration(intervalSeconds) * time.Second
+	if interval < MinInterval {
+		return Schedule{}, fmt.Errorf("interval must be at least %d seconds", int(MinInterval.Seconds()))
+	}
+	return Schedule{interval: interval}, nil
+}
+
+// Next returns the first run strictly after now. Interval schedules stay
+// aligned to previous and skip the runs that were missed.
+func (schedule Schedule) Next(previous time.Time, now time.Time) time.Time {
+	if schedule.cron != nil {
+		return schedule.cron.Next(now.UTC())
+	}
+	next := previous.Add(schedule.interval)
+	if !next.After(now) {
+		missed := now.Sub(previous) / schedule.interval
+		next = previous.Add((missed + 1) * schedule.interval)
+	}
+	return next
+}
@@ -0,0 +1,45 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	_, err := Parse("", 0)
+	require.ErrorIs(t, err, ErrInvalidSchedule)
+
+	_, err = Parse("0 9 * * 1", 3600)
+	require.ErrorIs(t, err, ErrInvalidSchedule)
+
+	_, err = Parse("not a cron", 0)
+	require.Error(t, err)
+
+	_, err = Parse("", 30)
+	require.Error(t, err)
+
+	_, err = Parse("@monthly", 0)
+	require.NoError(t, err)
+}
+
+func TestNextCron(t *testing.T) {
+	schedule, err := Parse("0 9 1 * *", 0)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC), schedule.Next(now, now))
+}
+
+func TestNextInterval(t *testing.T) {
+	schedule, err := Parse("", 3600)
+	require.NoError(t, err)
+
+	previous := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, previous.Add(time.Hour), schedule.Next(previous, previous))
+
+	// missed runs are skipped, the schedule stays aligned to previous
+	now := previous.Add(5*time.Hour + 30*time.Minute)
+	require.Equal(t, previous.Add(6*time.Hour), schedule.Next(previous, now))
+}
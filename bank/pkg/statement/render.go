@@ -0,0 +1,102 @@
+package statement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04:05"
+)
+
+func Render(w io.Writer, statement Statement, format Format) error {
+	switch format {
+	case FormatCSV:
+		return renderCSV(w, statement)
+	case FormatText:
+		return renderText(w, statement)
+	case FormatHTML:
+		return renderHTML(w, statement)
+	}
+	return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+func renderCSV(w io.Writer, statement Statement) error {
+	writer := csv.NewWriter(w)
+	records := [][]string{
+		{"date", "entry_id", "description", "counterparty_account_id", "amount", "balance"},
+		{statement.From.UTC().Format(dateTimeLayout), "", "Opening balance", "", "", FormatAmount(statement.OpeningBalance, statement.Currency)},
+	}
+	for _, line := range statement.Lines {
+		counterparty := ""
+		if line.CounterpartyAccountID != 0 {
+			counterparty = strconv.FormatInt(line.CounterpartyAccountID, 10)
+		}
+		records = append(records, []string{
+			line.Date.UTC().Format(dateTimeLayout),
+			strconv.FormatInt(line.EntryID, 10),
+			line.Description,
+			counterparty,
+			FormatAmount(line.Amount, statement.Currency),
+			FormatAmount(line.Balance, statement.Currency),
+		})
+	}
+	records = append(records, []string{statement.To.UTC().Format(dateTimeLayout), "", "Closing balance", "", "", FormatAmount(statement.ClosingBalance, statement.Currency)})
+	return writer.WriteAll(records)
+}
+
+func renderText(w io.Writer, statement Statement) error {
+	fmt.Fprintf(w, "Account statement\n")
+	fmt.Fprintf(w, "Account:  %d (%s)\n", statement.AccountID, statement.Currency)
+	fmt.Fprintf(w, "Period:   %s - %s (UTC)\n\n", statement.From.UTC().Format(dateTimeLayout), statement.To.UTC().Format(dateTimeLayout))
+	fmt.Fprintf(w, "Opening balance: %s\n\n", FormatAmount(statement.OpeningBalance, statement.Currency))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Date\tEntry\tDescription\tAmount\tBalance\t")
+	for _, line := range statement.Lines {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t\n",
+			line.Date.UTC().Format(dateTimeLayout),
+			line.EntryID,
+			line.Description,
+			FormatAmount(line.Amount, statement.Currency),
+			FormatAmount(line.Balance, statement.Currency),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nClosing balance: %s\n", FormatAmount(statement.ClosingBalance, statement.Currency))
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("statement").Funcs(template.FuncMap{
+	"amount":   func(amount int64, currency string) string { return FormatAmount(amount, currency) },
+	"datetime": func(value time.Time) string { return value.UTC().Format(dateTimeLayout) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Statement for account {{.AccountID}}</title></head>
+<body>
+<h1>Account statement</h1>
+<p>Account: {{.AccountID}} ({{.Currency}})<br/>Period: {{datetime .From}} - {{datetime .To}} (UTC)</p>
+<p>Opening balance: {{amount .OpeningBalance .Currency}}</p>
+<table border="1" cellpadding="4" cellspacing="0">
+<tr><th>Date</th><th>Entry</th><th>Description</th><th>Amount</th><th>Balance</th></tr>
+{{- range .Lines}}
+<tr><td>{{datetime .Date}}</td><td>{{.EntryID}}</td><td>{{.Description}}</td><td>{{amount .Amount $.Currency}}</td><td>{{amount .Balance $.Currency}}</td></tr>
+{{- end}}
+</table>
+<p>Closing balance: {{amount .ClosingBalance .Currency}}</p>
+</body>
+</html>
+`))
+
+func renderHTML(w io.Writer, statement Statement) error {
+	return htmlTemplate.Execute(w, statement)
+}
@@ -0,0 +1,125 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/util"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatText Format = "text"
+	FormatHTML Format = "html"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported statement format")
+
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(value)); format {
+	case FormatCSV, FormatText, FormatHTML:
+		return format, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, value)
+}
+
+// Line is one entry of the statement with the balance right after it.
+// CounterpartyAccountID is zero for entries that don't come from a transfer.
+type Line struct {
+	EntryID               int64
+	Date                  time.Time
+	Description           string
+	CounterpartyAccountID int64
+	Amount                int64
+	Balance               int64
+}
+
+// Statement covers the entries of an account in [From, To).
+type Statement struct {
+	AccountID      int64
+	Currency       string
+	From           time.Time
+	To             time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+	Lines          []Line
+}
+
+func New(result db.AccountStatementTxResult, from time.Time, to time.Time) Statement {
+	statement := Statement{
+		AccountID:      result.Account.ID,
+		Currency:       result.Account.Currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: result.OpeningBalance,
+		Lines:          make([]Line, 0, len(result.Entries)),
+	}
+
+	balance := result.OpeningBalance
+	for _, entry := range result.Entries {
+		balance += entry.Amount
+		line := Line{
+			EntryID:     entry.ID,
+			Date:        entry.CreatedAt,
+			Description: "Adjustment",
+			Amount:      entry.Amount,
+			Balance:     balance,
+		}
+		if entry.TransferID.Valid {
+			if entry.Amount < 0 {
+				line.CounterpartyAccountID = entry.ToAccountID.Int64
+				line.Description = fmt.Sprintf("Transfer %d to account %d", entry.TransferID.Int64, line.CounterpartyAccountID)
+			} else {
+				line.CounterpartyAccountID = entry.FromAccountID.Int64
+				line.Description = fmt.Sprintf("Transfer %d from account %d", entry.TransferID.Int64, line.CounterpartyAccountID)
+			}
+		}
+		statement.Lines = append(statement.Lines, line)
+	}
+	statement.ClosingBalance = balance
+	return statement
+}
+
+func ContentType(format Format) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+func FileName(statement Statement, format Format) string {
+	extension := "txt"
+	switch format {
+	case FormatCSV:
+		extension = "csv"
+	case FormatHTML:
+		extension = "html"
+	}
+	return fmt.Sprintf("statement-%d-%s-%s.%s", statement.AccountID, statement.From.UTC().Format(dateLayout), statement.To.UTC().Format(dateLayout), extension)
+}
+
+// FormatAmount prints an amount stored in the currency's minor unit, e.g.
+// -12345 USD as -123.45.
+func FormatAmount(amount int64, currency string) string {
+	places := util.CurrencyMinorUnits(currency)
+	if places == 0 {
+		return fmt.Sprintf("%d", amount)
+	}
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	scale := int64(1)
+	for i := 0; i < places; i++ {
+		scale *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/scale, places, amount%scale)
+}
@@ -0,0 +1,96 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	db "main/db/sqlc"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testStatement() Statement {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	return New(db.AccountStatementTxResult{
+		Account:        db.Account{ID: 1, Currency: "USD"},
+		OpeningBalance: 10000,
+		Entries: []db.ListStatementEntriesRow{
+			{
+				ID:            11,
+				Amount:        -2550,
+				CreatedAt:     from.Add(time.Hour),
+				TransferID:    sql.NullInt64{Int64: 5, Valid: true},
+				FromAccountID: sql.NullInt64{Int64: 1, Valid: true},
+				ToAccountID:   sql.NullInt64{Int64: 2, Valid: true},
+			},
+			{
+				ID:            14,
+				Amount:        1000,
+				CreatedAt:     from.Add(48 * time.Hour),
+				TransferID:    sql.NullInt64{Int64: 7, Valid: true},
+				FromAccountID: sql.NullInt64{Int64: 3, Valid: true},
+				ToAccountID:   sql.NullInt64{Int64: 1, Valid: true},
+			},
+			{
+				ID:        20,
+				Amount:    5,
+				CreatedAt: from.Add(72 * time.Hour),
+			},
+		},
+	}, from, to)
+}
+
+func TestNew(t *testing.T) {
+	statement := testStatement()
+
+	require.Len(t, statement.Lines, 3)
+	require.Equal(t, int64(2), statement.Lines[0].CounterpartyAccountID)
+	require.Equal(t, int64(7450), statement.Lines[0].Balance)
+	require.Equal(t, int64(3), statement.Lines[1].CounterpartyAccountID)
+	require.Equal(t, int64(8450), statement.Lines[1].Balance)
+	require.Zero(t, statement.Lines[2].CounterpartyAccountID)
+	require.Equal(t, int64(8455), statement.ClosingBalance)
+}
+
+func TestRenderCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testStatement(), FormatCSV))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 6)
+	require.Equal(t, "date,entry_id,description,counterparty_account_id,amount,balance", lines[0])
+	require.Equal(t, "2024-01-01 00:00:00,,Opening balance,,,100.00", lines[1])
+	require.Equal(t, "2024-01-01 01:00:00,11,Transfer 5 to account 2,2,-25.50,74.50", lines[2])
+	require.Equal(t, "2024-02-01 00:00:00,,Closing balance,,,84.55", lines[5])
+}
+
+func TestRenderTextAndHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testStatement(), FormatText))
+	require.Contains(t, buf.String(), "Opening balance: 100.00")
+	require.Contains(t, buf.String(), "Closing balance: 84.55")
+
+	buf.Reset()
+	require.NoError(t, Render(&buf, testStatement(), FormatHTML))
+	require.Contains(t, buf.String(), "<td>Transfer 7 from account 3</td>")
+	require.Contains(t, buf.String(), "Closing balance: 84.55")
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("CSV")
+	require.NoError(t, err)
+	require.Equal(t, FormatCSV, format)
+	require.Equal(t, "statement-1-2024-01-01-2024-02-01.csv", FileName(testStatement(), format))
+
+	_, err = ParseFormat("pdf")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "0.05", FormatAmount(5, "USD"))
+	require.Equal(t, "-123.45", FormatAmount(-12345, "EUR"))
+	require.Equal(t, "12345", FormatAmount(12345, "VND"))
+}
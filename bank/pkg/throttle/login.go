@@ -0,0 +1,172 @@
+package throttle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	db "main/db/sqlc"
+	"main/util"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	ScopeEmail = "email"
+	ScopeIP    = "ip"
+)
+
+// LockedError is returned by Check while logins for an email or a client ip
+// are refused.
+type LockedError struct {
+	Until time.Time
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, try again in %s", time.Until(e.Until).Round(time.Second))
+}
+
+// Config bounds the failed logins. Every failure delays the next attempt by
+// BackoffBase doubled per previous failure, reaching MaxAttempts for an email
+// or MaxAttemptsPerIP for a client ip locks it for LockoutDuration. Failures
+// older than LockoutDuration are forgotten.
+type Config struct {
+	MaxAttempts      int32
+	MaxAttemptsPerIP int32
+	BackoffBase      time.Duration
+	LockoutDuration  time.Duration
+}
+
+// LoginThrottle tracks failed logins per email and per client ip in Postgres
+// so every instance of the server shares them.
+type LoginThrottle struct {
+	store  db.Store
+	config Config
+	now    func() time.Time
+}
+
+func NewLoginThrottle(store db.Store, config util.Config) *LoginThrottle {
+	return &LoginThrottle{
+		store: store,
+		config: Config{
+			MaxAttempts:      config.LoginMaxAttempts,
+			MaxAttemptsPerIP: config.LoginMaxAttemptsPerIP,
+			BackoffBase:      config.LoginBackoffBase,
+			LockoutDuration:  config.LoginLockoutDuration,
+		},
+		now: time.Now,
+	}
+}
+
+// Check returns a *LockedError when the email or the client ip has to wait
+// before trying again.
+func (throttle *LoginThrottle) Check(ctx context.Context, email, clientIP string) error {
+	for _, key := range keys(email, clientIP) {
+		attempt, err := throttle.store.GetLoginAttempt(ctx, db.GetLoginAttemptParams{
+			Scope:      key.scope,
+			Identifier: key.identifier,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+			return fmt.Errorf("failed to get login attempt: %w", err)
+		}
+		if throttle.now().Before(attempt.LockedUntil) {
+			return &LockedError{Until: attempt.LockedUntil}
+		}
+	}
+	return nil
+}
+
+// Fail records a failed login and reports whether it locked the email out,
+// which only happens once per lockout.
+func (throttle *LoginThrottle) Fail(ctx context.Context, email, clientIP string) (bool, error) {
+	now := throttle.now()
+	lockedOut := false
+	for _, key := range keys(email, clientIP) {
+		attempt, err := throttle.store.RecordFailedLogin(ctx, db.RecordFailedLoginParams{
+			Scope:       key.scope,
+			Identifier:  key.identifier,
+			ResetBefore: now.Add(-throttle.config.LockoutDuration),
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to record failed login: %w", err)
+		}
+
+		maxAttempts := throttle.config.MaxAttempts
+		if key.scope == ScopeIP {
+			maxAttempts = throttle.config.MaxAttemptsPerIP
+		}
+		delay := throttle.config.delay(attempt.FailedCount, maxAttempts)
+		_, err = throttle.store.LockLogin(ctx, db.LockLoginParams{
+			LockedUntil: now.Add(delay),
+			Scope:       key.scope,
+			Identifier:  key.identifier,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to lock login: %w", err)
+		}
+		if key.scope == ScopeEmail && attempt.FailedCount == maxAttempts {
+			lockedOut = true
+		}
+	}
+	return lockedOut, nil
+}
+
+// Succeed forgets the failed logins of the email. Those of the client ip are
+// kept, otherwise logging into one account would reset the guessing of others.
+func (throttle *LoginThrottle) Succeed(ctx context.Context, email string) error {
+	err := throttle.store.DeleteLoginAttempt(ctx, db.DeleteLoginAttemptParams{
+		Scope:      ScopeEmail,
+		Identifier: normalizeEmail(email),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
+
+func (config Config) delay(failedCount, maxAttempts int32) time.Duration {
+	if failedCount >= maxAttempts {
+		return config.LockoutDuration
+	}
+	delay := config.BackoffBase
+	for i := int32(1); i < failedCount && delay < config.LockoutDuration; i++ {
+		delay *= 2
+	}
+	if delay > config.LockoutDuration {
+		return config.LockoutDuration
+	}
+	return delay
+}
+
+type key struct {
+	scope      string
+	identifier string
+}
+
+func keys(email, clientIP string) []key {
+	result := []key{{scope: ScopeEmail, identifier: normalizeEmail(email)}}
+	if ip := normalizeIP(clientIP); ip != "" {
+		result = append(result, key{scope: ScopeIP, identifier: ip})
+	}
+	return result
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizeIP drops the port the peer address of a grpc call comes with,
+// anything that isn't a single ip gets no bucket of its own.
+func normalizeIP(clientIP string) string {
+	if host, _, err := net.SplitHostPort(clientIP); err == nil {
+		clientIP = host
+	}
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
@@ -0,0 +1,87 @@
+package throttle
+
+import (
+	"context"
+	"database/sql"
+	mockdb "main/db/mock"
+	db "main/db/sqlc"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testConfig = Config{
+	MaxAttempts:      5,
+	MaxAttemptsPerIP: 20,
+	BackoffBase:      time.Second,
+	LockoutDuration:  15 * time.Minute,
+}
+
+func TestDelay(t *testing.T) {
+	require.Equal(t, time.Second, testConfig.delay(1, 5))
+	require.Equal(t, 8*time.Second, testConfig.delay(4, 5))
+	require.Equal(t, 15*time.Minute, testConfig.delay(5, 5))
+	// the backoff never waits longer than a lockout
+	require.Equal(t, 15*time.Minute, testConfig.delay(19, 20))
+}
+
+func TestLoginThrottleFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	now := time.Now()
+	throttle := NewLoginThrottle(store, util.Config{})
+	throttle.config = testConfig
+	throttle.now = func() time.Time { return now }
+
+	store.EXPECT().RecordFailedLogin(gomock.Any(), db.RecordFailedLoginParams{
+		Scope:       ScopeEmail,
+		Identifier:  "user@example.com",
+		ResetBefore: now.Add(-testConfig.LockoutDuration),
+	}).Return(db.LoginAttempt{FailedCount: 5}, nil)
+	store.EXPECT().LockLogin(gomock.Any(), db.LockLoginParams{
+		LockedUntil: now.Add(testConfig.LockoutDuration),
+		Scope:       ScopeEmail,
+		Identifier:  "user@example.com",
+	})
+	store.EXPECT().RecordFailedLogin(gomock.Any(), db.RecordFailedLoginParams{
+		Scope:       ScopeIP,
+		Identifier:  "10.0.0.1",
+		ResetBefore: now.Add(-testConfig.LockoutDuration),
+	}).Return(db.LoginAttempt{FailedCount: 2}, nil)
+	store.EXPECT().LockLogin(gomock.Any(), db.LockLoginParams{
+		LockedUntil: now.Add(2 * time.Second),
+		Scope:       ScopeIP,
+		Identifier:  "10.0.0.1",
+	})
+
+	lockedOut, err := throttle.Fail(context.Background(), " User@Example.com", "10.0.0.1:52814")
+	require.NoError(t, err)
+	require.True(t, lockedOut)
+}
+
+func TestLoginThrottleCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	now := time.Now()
+	throttle := NewLoginThrottle(store, util.Config{})
+	throttle.config = testConfig
+	throttle.now = func() time.Time { return now }
+
+	store.EXPECT().GetLoginAttempt(gomock.Any(), db.GetLoginAttemptParams{Scope: ScopeEmail, Identifier: "user@example.com"}).
+		Times(2).Return(db.LoginAttempt{}, sql.ErrNoRows)
+	store.EXPECT().GetLoginAttempt(gomock.Any(), db.GetLoginAttemptParams{Scope: ScopeIP, Identifier: "10.0.0.1"}).
+		Times(2).Return(db.LoginAttempt{LockedUntil: now.Add(time.Minute)}, nil)
+
+	err := throttle.Check(context.Background(), "user@example.com", "10.0.0.1")
+	var lockedErr *LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	require.Equal(t, now.Add(time.Minute), lockedErr.Until)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, throttle.Check(context.Background(), "user@example.com", "10.0.0.1"))
+}
@@ -0,0 +1,46 @@
+package totp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"main/util"
+	"strings"
+)
+
+// RecoveryCodeCount is the number of recovery codes handed out when
+// two-factor authentication is enabled.
+const RecoveryCodeCount = 10
+
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// GenerateRecoveryCodes returns n single use codes formatted as xxxxx-xxxxx.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	b := make([]byte, 10)
+	for i := range codes {
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		var sb strings.Builder
+		for j, c := range b {
+			if j == 5 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryAlphabet[int(c)%len(recoveryAlphabet)])
+		}
+		codes[i] = sb.String()
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash stored for a recovery code, case and
+// separators typed by the user don't matter.
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+	return util.HashSecretToken(normalized)
+}
@@ -0,0 +1,76 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// the RFC lists 8 digit codes, these are their last 6 digits
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+	for _, tc := range testCases {
+		code, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	now := time.Now()
+
+	code, err := Code(secret, Step(now)-1)
+	require.NoError(t, err)
+	step, err := Validate(code, secret, now)
+	require.NoError(t, err)
+	require.Equal(t, Step(now)-1, step)
+
+	code, err = Code(secret, Step(now)-2)
+	require.NoError(t, err)
+	_, err = Validate(code, secret, now)
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	_, err = Validate("12345", secret, now)
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	_, err = Validate("123456", "not base32!", now)
+	require.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Simple Bank", "john@example.com", rfcSecret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Simple%20Bank:john@example.com?"))
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, rfcSecret, parsed.Query().Get("secret"))
+	require.Equal(t, "Simple Bank", parsed.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+	require.Len(t, codes[0], 11)
+	require.NotEqual(t, codes[0], codes[1])
+
+	require.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))))
+}
@@ -81,3 +81,42 @@ func ValidateEmailId(value int64) error {
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("is not a supported currency")
+	}
+	return nil
+}
+
+func ValidateID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidatePage(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateLimit(value int32) error {
+	if value < 1 || value > 100 {
+		return fmt.Errorf("must be from 1-100")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateIdempotencyKey(value string) error {
+	return ValidateString(value, 1, 255)
+}
@@ -0,0 +1,101 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	db "main/db/sqlc"
+	"main/util"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), blockType+".pem")
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+// newKeyFiles writes a PKCS8 private key and its PKIX public key for the maker.
+func newKeyFiles(t *testing.T, tokenMaker string) (string, string) {
+	var privateKey, publicKey any
+	if tokenMaker == MakerJWTRS256 {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		privateKey, publicKey = key, &key.PublicKey
+	} else {
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		privateKey, publicKey = key, pub
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	return writePEM(t, "PRIVATE KEY", privateDER), writePEM(t, "PUBLIC KEY", publicDER)
+}
+
+func TestAsymmetricMakers(t *testing.T) {
+	for _, tokenMaker := range []string{MakerPasetoPublic, MakerJWTEdDSA, MakerJWTRS256} {
+		t.Run(tokenMaker, func(t *testing.T) {
+			privateKeyFile, publicKeyFile := newKeyFiles(t, tokenMaker)
+			signer, err := NewKeyring(util.Config{TokenMaker: tokenMaker, TokenPrivateKeyFile: privateKeyFile})
+			require.NoError(t, err)
+			verifier, err := NewKeyring(util.Config{TokenMaker: tokenMaker, TokenPublicKeyFile: publicKeyFile})
+			require.NoError(t, err)
+
+			sessionID := uuid.New()
+			token, payload, err := signer.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, sessionID, time.Minute)
+			require.NoError(t, err)
+
+			verified, err := verifier.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, payload.ID, verified.ID)
+			require.Equal(t, sessionID, verified.SessionID)
+			require.Equal(t, TokenTypeAccess, verified.Type)
+			require.WithinDuration(t, payload.ExpiredAt, verified.ExpiredAt, time.Second)
+
+			_, _, err = verifier.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, sessionID, time.Minute)
+			require.ErrorIs(t, err, ErrVerifyOnly)
+
+			expired, _, err := signer.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, sessionID, -time.Minute)
+			require.NoError(t, err)
+			_, err = verifier.VerifyToken(expired)
+			require.ErrorIs(t, err, ErrExpiredToken)
+
+			// a token of another key pair is refused
+			otherKeyFile, _ := newKeyFiles(t, tokenMaker)
+			other, err := NewKeyring(util.Config{TokenMaker: tokenMaker, TokenPrivateKeyFile: otherKeyFile})
+			require.NoError(t, err)
+			forged, _, err := other.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, sessionID, time.Minute)
+			require.NoError(t, err)
+			_, err = verifier.VerifyToken(forged)
+			require.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestJWTAsymmetricMakerRefusesHS256(t *testing.T) {
+	_, publicKeyFile := newKeyFiles(t, MakerJWTRS256)
+	verifier, err := NewKeyring(util.Config{TokenMaker: MakerJWTRS256, TokenPublicKeyFile: publicKeyFile})
+	require.NoError(t, err)
+
+	publicKeyPEM, err := os.ReadFile(publicKeyFile)
+	require.NoError(t, err)
+	payload, err := NewPayload(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+	require.NoError(t, err)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, payload).SignedString(publicKeyPEM)
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(token)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
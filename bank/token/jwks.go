@@ -0,0 +1,124 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+// Values of the alg member of the published keys. JWKS has no algorithm
+// for PASETO, its keys are marked with the version and purpose they sign.
+const (
+	AlgPasetoV4Public = "v4.public"
+	AlgEdDSA          = "EdDSA"
+	AlgRS256          = "RS256"
+)
+
+// JSONWebKey is a public key as published in a JWKS (RFC 7517), Ed25519 keys
+// use the OKP key type of RFC 8037.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys tokens are verified with, the active one
+// first. It's empty for a symmetric keyring.
+func (keyring *Keyring) JWKS() JSONWebKeySet {
+	keyring.mu.RLock()
+	keys := keyring.keys
+	keyring.mu.RUnlock()
+
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range keys {
+		jwk := JSONWebKey{Kty: "OKP", Use: "sig", Alg: key.alg, Kid: key.id}
+		switch publicKey := key.publicKey.(type) {
+		case ed25519.PublicKey:
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// JWKSHandler serves the JWKS at /.well-known/jwks.json. Clients may cache it
+// for maxAge, a key stays in the set for as long as it's in the config so a
+// rotation has to keep the previous key at least that long.
+func (keyring *Keyring) JWKSHandler(maxAge time.Duration) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			res.Header().Set("Allow", "GET, HEAD")
+			http.Error(res, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := json.Marshal(keyring.JWKS())
+		if err != nil {
+			http.Error(res, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+		res.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+		res.Header().Set("ETag", etag)
+		if req.Header.Get("If-None-Match") == etag {
+			res.WriteHeader(http.StatusNotModified)
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(body)
+	})
+}
+
+// parseJSONWebKey returns a maker that verifies the tokens of the key, the
+// maker of a key with an unknown type or alg is nil.
+func parseJSONWebKey(jwk JSONWebKey) (keyedMaker, error) {
+	switch {
+	case jwk.Kty == "OKP" && jwk.Crv == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key %s", jwk.Kid)
+		}
+		switch jwk.Alg {
+		case AlgPasetoV4Public:
+			return newPasetoPublicMaker(nil, ed25519.PublicKey(x), jwk.Kid)
+		case AlgEdDSA:
+			return newJWTEdDSAMaker(nil, ed25519.PublicKey(x), jwk.Kid)
+		}
+	case jwk.Kty == "RSA" && jwk.Alg == AlgRS256:
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa key %s", jwk.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid rsa key %s", jwk.Kid)
+		}
+		publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		return newJWTRS256Maker(nil, publicKey, jwk.Kid)
+	}
+	return nil, nil
+}
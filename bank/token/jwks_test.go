@@ -0,0 +1,184 @@
+package token
+
+import (
+	db "main/db/sqlc"
+	"main/util"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	privateKeyFile, _ := newKeyFiles(t, MakerJWTEdDSA)
+	keyring, err := NewKeyring(util.Config{TokenMaker: MakerJWTEdDSA, TokenPrivateKeyFile: privateKeyFile})
+	require.NoError(t, err)
+	handler := keyring.JWKSHandler(5 * time.Minute)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "public, max-age=300", recorder.Header().Get("Cache-Control"))
+	require.Contains(t, recorder.Body.String(), keyring.ActiveKeyID())
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	req.Header.Set("If-None-Match", recorder.Header().Get("ETag"))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusNotModified, recorder.Code)
+
+	// symmetric keys are never published
+	symmetric, err := NewKeyring(util.Config{TokenSymmetricKey: util.RandomStr(32)})
+	require.NoError(t, err)
+	require.Empty(t, symmetric.JWKS().Keys)
+}
+
+func TestRemoteVerifier(t *testing.T) {
+	for _, tokenMaker := range []string{MakerPasetoPublic, MakerJWTEdDSA, MakerJWTRS256} {
+		t.Run(tokenMaker, func(t *testing.T) {
+			oldKeyFile, oldPublicKeyFile := newKeyFiles(t, tokenMaker)
+			keyring, err := NewKeyring(util.Config{TokenMaker: tokenMaker, TokenPrivateKeyFile: oldKeyFile})
+			require.NoError(t, err)
+
+			fetches := 0
+			server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				fetches++
+				keyring.JWKSHandler(time.Minute).ServeHTTP(res, req)
+			}))
+			defer server.Close()
+			verifier := NewRemoteVerifier(server.URL, time.Hour)
+
+			oldToken, payload, err := keyring.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+			require.NoError(t, err)
+			verified, err := verifier.VerifyToken(oldToken)
+			require.NoError(t, err)
+			require.Equal(t, payload.ID, verified.ID)
+
+			// a token of a new key makes the verifier fetch the keys again
+			newKeyFile, _ := newKeyFiles(t, tokenMaker)
+			err = keyring.Reload(util.Config{
+				TokenMaker:                  tokenMaker,
+				TokenPrivateKeyFile:         newKeyFile,
+				TokenPreviousPublicKeyFiles: []string{oldPublicKeyFile},
+			})
+			require.NoError(t, err)
+			newToken, _, err := keyring.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+			require.NoError(t, err)
+
+			now := time.Now().Add(minRefreshInterval)
+			verifier.now = func() time.Time { return now }
+			_, err = verifier.VerifyToken(newToken)
+			require.NoError(t, err)
+			_, err = verifier.VerifyToken(oldToken)
+			require.NoError(t, err)
+			require.Equal(t, 2, fetches)
+
+			_, _, err = verifier.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+			require.ErrorIs(t, err, ErrVerifyOnly)
+		})
+	}
+}
+
+func TestRemoteVerifierStaleKeys(t *testing.T) {
+	keyFile, _ := newKeyFiles(t, MakerJWTEdDSA)
+	keyring, err := NewKeyring(util.Config{TokenMaker: MakerJWTEdDSA, TokenPrivateKeyFile: keyFile})
+	require.NoError(t, err)
+
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if failing.Load() {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		keyring.JWKSHandler(time.Minute).ServeHTTP(res, req)
+	}))
+	defer server.Close()
+	verifier := NewRemoteVerifier(server.URL, time.Hour)
+
+	start := time.Now()
+	token, _, err := keyring.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+	require.NoError(t, err)
+	_, err = verifier.VerifyToken(token)
+	require.NoError(t, err)
+
+	// the last keys are still trusted for a while when the issuer is down
+	failing.Store(true)
+	now := start.Add(time.Hour + staleKeysGrace/2)
+	verifier.now = func() time.Time { return now }
+	_, err = verifier.VerifyToken(token)
+	require.NoError(t, err)
+
+	now = start.Add(time.Hour + staleKeysGrace + time.Second)
+	_, err = verifier.VerifyToken(token)
+	require.Error(t, err)
+	_, err = verifier.VerifyToken(token)
+	require.ErrorIs(t, err, errStaleKeys)
+
+	failing.Store(false)
+	now = now.Add(minRefreshInterval)
+	_, err = verifier.VerifyToken(token)
+	require.NoError(t, err)
+}
+
+func TestRemoteVerifierConcurrentFetch(t *testing.T) {
+	keyFile, _ := newKeyFiles(t, MakerJWTEdDSA)
+	keyring, err := NewKeyring(util.Config{TokenMaker: MakerJWTEdDSA, TokenPrivateKeyFile: keyFile})
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if fetches.Add(1) > 1 {
+			started <- struct{}{}
+			<-release
+		}
+		keyring.JWKSHandler(time.Minute).ServeHTTP(res, req)
+	}))
+	defer server.Close()
+	verifier := NewRemoteVerifier(server.URL, time.Hour)
+
+	token, _, err := keyring.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+	require.NoError(t, err)
+	_, err = verifier.VerifyToken(token)
+	require.NoError(t, err)
+
+	otherKeyFile, _ := newKeyFiles(t, MakerJWTEdDSA)
+	other, err := NewKeyring(util.Config{TokenMaker: MakerJWTEdDSA, TokenPrivateKeyFile: otherKeyFile})
+	require.NoError(t, err)
+	unknownToken, _, err := other.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	now := time.Now().Add(minRefreshInterval)
+	verifier.now = func() time.Time { return now }
+
+	// tokens of an unknown key share a single fetch
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := verifier.VerifyToken(unknownToken)
+			errs <- err
+		}()
+	}
+	<-started
+
+	// tokens of a known key don't wait for it
+	_, err = verifier.VerifyToken(token)
+	require.NoError(t, err)
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.ErrorIs(t, err, ErrInvalidToken)
+	}
+	require.Equal(t, int32(2), fetches.Load())
+}
@@ -0,0 +1,117 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+// JWTAsymmetricMaker signs JWTs with an RS256 or EdDSA private key, a maker
+// made from the public key alone only verifies them.
+type JWTAsymmetricMaker struct {
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+	keyID      string
+}
+
+func NewJWTRS256Maker(privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey) (Maker, error) {
+	return newJWTRS256Maker(privateKey, publicKey, "")
+}
+
+func newJWTRS256Maker(privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey, keyID string) (*JWTAsymmetricMaker, error) {
+	if privateKey != nil {
+		return &JWTAsymmetricMaker{method: jwt.SigningMethodRS256, privateKey: privateKey, publicKey: &privateKey.PublicKey, keyID: keyID}, nil
+	}
+	if publicKey == nil {
+		return nil, ErrMissingKey
+	}
+	return &JWTAsymmetricMaker{method: jwt.SigningMethodRS256, publicKey: publicKey, keyID: keyID}, nil
+}
+
+func NewJWTEdDSAMaker(privateKey ed25519.PrivateKey, publicKey ed25519.PublicKey) (Maker, error) {
+	return newJWTEdDSAMaker(privateKey, publicKey, "")
+}
+
+func newJWTEdDSAMaker(privateKey ed25519.PrivateKey, publicKey ed25519.PublicKey, keyID string) (*JWTAsymmetricMaker, error) {
+	if privateKey != nil {
+		return &JWTAsymmetricMaker{method: jwt.SigningMethodEdDSA, privateKey: privateKey, publicKey: privateKey.Public(), keyID: keyID}, nil
+	}
+	if publicKey == nil {
+		return nil, ErrMissingKey
+	}
+	return &JWTAsymmetricMaker{method: jwt.SigningMethodEdDSA, publicKey: publicKey, keyID: keyID}, nil
+}
+
+func (maker *JWTAsymmetricMaker) CreateToken(tokenType TokenType, userID, email string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	if maker.privateKey == nil {
+		return "", nil, ErrVerifyOnly
+	}
+	payload, err := NewPayload(tokenType, userID, email, role, sessionID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	jwtToken := jwt.NewWithClaims(maker.method, payload)
+	setJWTKeyID(jwtToken, maker.keyID)
+	token, err := jwtToken.SignedString(maker.privateKey)
+	if err != nil {
+		return "", payload, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, payload, nil
+}
+
+func (maker *JWTAsymmetricMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		// refuses HS256 tokens signed with the public key as the secret
+		if token.Method.Alg() != maker.method.Alg() {
+			return nil, ErrInvalidToken
+		}
+		return maker.publicKey, nil
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && errors.Is(verr.Inner, ErrExpiredToken) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	payload, ok := jwtToken.Claims.(*Payload)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
+
+func (maker *JWTAsymmetricMaker) tokenKeyID(token string) string {
+	return jwtKeyID(token)
+}
+
+// setJWTKeyID names the key in the kid header, tokens of a maker without key
+// id have none.
+func setJWTKeyID(jwtToken *jwt.Token, keyID string) {
+	if keyID != "" {
+		jwtToken.Header["kid"] = keyID
+	}
+}
+
+// jwtKeyID reads the kid header without checking the signature, it only
+// picks the key the token is then verified with.
+func jwtKeyID(token string) string {
+	jwtToken, _, err := new(jwt.Parser).ParseUnverified(token, &Payload{})
+	if err != nil {
+		return ""
+	}
+	keyID, _ := jwtToken.Header["kid"].(string)
+	return keyID
+}
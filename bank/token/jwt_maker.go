@@ -7,27 +7,34 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 )
 
 const MinSecretKeySize = 32
 
 type JWTMaker struct {
 	secretKey string
+	keyID     string
 }
 
 func NewJWTMaker(secretKey string) (Maker, error) {
+	return newJWTMaker(secretKey, "")
+}
+
+func newJWTMaker(secretKey, keyID string) (*JWTMaker, error) {
 	if len(secretKey) < MinSecretKeySize {
 		return nil, fmt.Errorf("invalid key size: must be at least %d characters", MinSecretKeySize)
 	}
-	return &JWTMaker{secretKey}, nil
+	return &JWTMaker{secretKey: secretKey, keyID: keyID}, nil
 }
 
-func (maker *JWTMaker) CreateToken(userID, email string, role db.UserRole, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(userID, email, role, duration)
+func (maker *JWTMaker) CreateToken(tokenType TokenType, userID, email string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(tokenType, userID, email, role, sessionID, duration)
 	if err != nil {
 		return "", payload, err
 	}
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	setJWTKeyID(jwtToken, maker.keyID)
 	token, err := jwtToken.SignedString([]byte(maker.secretKey))
 	return token, payload, err
 }
@@ -57,3 +64,7 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 
 	return payload, nil
 }
+
+func (maker *JWTMaker) tokenKeyID(token string) string {
+	return jwtKeyID(token)
+}
@@ -0,0 +1,240 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	db "main/db/sqlc"
+	"main/util"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// keyFooter is the PASETO footer naming the key a token was made with, JWTs
+// carry the same id in their kid header.
+type keyFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// newKeyFooter leaves the footer out for makers without key id.
+func newKeyFooter(keyID string) interface{} {
+	if keyID == "" {
+		return nil
+	}
+	return keyFooter{KeyID: keyID}
+}
+
+// keyedMaker is a Maker that can tell which key id a token names, without
+// verifying it.
+type keyedMaker interface {
+	Maker
+	tokenKeyID(token string) string
+}
+
+type keyringKey struct {
+	id    string
+	maker keyedMaker
+	// alg and publicKey are published in the JWKS, symmetric keys have none
+	alg       string
+	publicKey crypto.PublicKey
+}
+
+// Keyring is the Maker of the servers. It signs with the active key and
+// verifies tokens made with any key still listed in the config, keys that
+// are dropped from it are retired and their tokens refused. Reload swaps the
+// keys in place so they can be rotated without a restart.
+type Keyring struct {
+	mu sync.RWMutex
+	// the active key comes first
+	keys []keyringKey
+}
+
+func NewKeyring(config util.Config) (*Keyring, error) {
+	keyring := &Keyring{}
+	if err := keyring.Reload(config); err != nil {
+		return nil, err
+	}
+	return keyring, nil
+}
+
+// Reload replaces the keys with the ones of the config, the current keys are
+// kept if the new ones can't be loaded.
+func (keyring *Keyring) Reload(config util.Config) error {
+	keys, err := loadKeyringKeys(config)
+	if err != nil {
+		return err
+	}
+
+	keyring.mu.Lock()
+	defer keyring.mu.Unlock()
+	keyring.keys = keys
+	return nil
+}
+
+func (keyring *Keyring) CreateToken(tokenType TokenType, userID, email string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	return keyring.activeKey().maker.CreateToken(tokenType, userID, email, role, sessionID, duration)
+}
+
+func (keyring *Keyring) VerifyToken(token string) (*Payload, error) {
+	keyring.mu.RLock()
+	keys := keyring.keys
+	keyring.mu.RUnlock()
+
+	keyID := keys[0].maker.tokenKeyID(token)
+	if keyID == "" {
+		// tokens made before key ids were introduced
+		for _, key := range keys {
+			payload, err := key.maker.VerifyToken(token)
+			if err != ErrInvalidToken {
+				return payload, err
+			}
+		}
+		return nil, ErrInvalidToken
+	}
+	for _, key := range keys {
+		if key.id == keyID {
+			return key.maker.VerifyToken(token)
+		}
+	}
+	return nil, ErrInvalidToken
+}
+
+// ActiveKeyID is the id new tokens are made with.
+func (keyring *Keyring) ActiveKeyID() string {
+	return keyring.activeKey().id
+}
+
+func (keyring *Keyring) activeKey() keyringKey {
+	keyring.mu.RLock()
+	defer keyring.mu.RUnlock()
+	return keyring.keys[0]
+}
+
+func loadKeyringKeys(config util.Config) ([]keyringKey, error) {
+	switch config.TokenMaker {
+	case MakerPaseto, MakerJWT, "":
+		secrets := append([]string{config.TokenSymmetricKey}, config.TokenPreviousSymmetricKeys...)
+		keys := make([]keyringKey, 0, len(secrets))
+		for _, secret := range secrets {
+			id := newKeyID([]byte(secret))
+			var maker keyedMaker
+			var err error
+			if config.TokenMaker == MakerJWT {
+				maker, err = newJWTMaker(secret, id)
+			} else {
+				maker, err = newPasetoMaker(secret, id)
+			}
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, keyringKey{id: id, maker: maker})
+		}
+		return keys, nil
+	}
+
+	// without a private key the service only verifies tokens
+	privateKeyPEM, err := readKeyFile(config.TokenPrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	publicKeyFiles := config.TokenPreviousPublicKeyFiles
+	var publicKeyPEM []byte
+	if privateKeyPEM == nil {
+		publicKeyPEM, err = readKeyFile(config.TokenPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	active, err := newAsymmetricKey(config.TokenMaker, privateKeyPEM, publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []keyringKey{active}
+	for _, path := range publicKeyFiles {
+		publicKeyPEM, err := readKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := newAsymmetricKey(config.TokenMaker, nil, publicKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func newAsymmetricKey(tokenMaker string, privateKeyPEM, publicKeyPEM []byte) (keyringKey, error) {
+	switch tokenMaker {
+	case MakerPasetoPublic, MakerJWTEdDSA:
+		privateKey, publicKey, err := loadEd25519Keys(privateKeyPEM, publicKeyPEM)
+		if err != nil {
+			return keyringKey{}, err
+		}
+		if privateKey != nil {
+			publicKey = privateKey.Public().(ed25519.PublicKey)
+		}
+		if publicKey == nil {
+			return keyringKey{}, ErrMissingKey
+		}
+		id, err := newPublicKeyID(publicKey)
+		if err != nil {
+			return keyringKey{}, err
+		}
+		var maker keyedMaker
+		alg := AlgPasetoV4Public
+		if tokenMaker == MakerPasetoPublic {
+			maker, err = newPasetoPublicMaker(privateKey, publicKey, id)
+		} else {
+			alg = AlgEdDSA
+			maker, err = newJWTEdDSAMaker(privateKey, publicKey, id)
+		}
+		if err != nil {
+			return keyringKey{}, err
+		}
+		return keyringKey{id: id, maker: maker, alg: alg, publicKey: publicKey}, nil
+	case MakerJWTRS256:
+		privateKey, publicKey, err := loadRSAKeys(privateKeyPEM, publicKeyPEM)
+		if err != nil {
+			return keyringKey{}, err
+		}
+		if privateKey != nil {
+			publicKey = &privateKey.PublicKey
+		}
+		if publicKey == nil {
+			return keyringKey{}, ErrMissingKey
+		}
+		id, err := newPublicKeyID(publicKey)
+		if err != nil {
+			return keyringKey{}, err
+		}
+		maker, err := newJWTRS256Maker(privateKey, publicKey, id)
+		if err != nil {
+			return keyringKey{}, err
+		}
+		return keyringKey{id: id, maker: maker, alg: AlgRS256, publicKey: publicKey}, nil
+	default:
+		return keyringKey{}, fmt.Errorf("unknown token maker %q", tokenMaker)
+	}
+}
+
+// newKeyID derives the id from the key itself so every instance of the
+// server names a key the same without configuring ids.
+func newKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+func newPublicKeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal public key: %w", err)
+	}
+	return newKeyID(der), nil
+}
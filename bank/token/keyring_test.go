@@ -0,0 +1,64 @@
+package token
+
+import (
+	db "main/db/sqlc"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	for _, tokenMaker := range []string{MakerPaseto, MakerJWT} {
+		t.Run(tokenMaker, func(t *testing.T) {
+			oldKey := util.RandomStr(32)
+			newKey := util.RandomStr(32)
+			keyring, err := NewKeyring(util.Config{TokenMaker: tokenMaker, TokenSymmetricKey: oldKey})
+			require.NoError(t, err)
+			oldKeyID := keyring.ActiveKeyID()
+
+			oldToken, _, err := keyring.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+			require.NoError(t, err)
+
+			err = keyring.Reload(util.Config{TokenMaker: tokenMaker, TokenSymmetricKey: newKey, TokenPreviousSymmetricKeys: []string{oldKey}})
+			require.NoError(t, err)
+			require.NotEqual(t, oldKeyID, keyring.ActiveKeyID())
+
+			newToken, _, err := keyring.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+			require.NoError(t, err)
+			_, err = keyring.VerifyToken(oldToken)
+			require.NoError(t, err)
+			_, err = keyring.VerifyToken(newToken)
+			require.NoError(t, err)
+
+			// the old key is retired once it's left out of the config
+			err = keyring.Reload(util.Config{TokenMaker: tokenMaker, TokenSymmetricKey: newKey})
+			require.NoError(t, err)
+			_, err = keyring.VerifyToken(oldToken)
+			require.ErrorIs(t, err, ErrInvalidToken)
+			_, err = keyring.VerifyToken(newToken)
+			require.NoError(t, err)
+
+			// a config that can't be loaded keeps the current keys
+			err = keyring.Reload(util.Config{TokenMaker: tokenMaker, TokenSymmetricKey: "short"})
+			require.Error(t, err)
+			_, err = keyring.VerifyToken(newToken)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestKeyringVerifiesTokensWithoutKeyID(t *testing.T) {
+	key := util.RandomStr(32)
+	maker, err := NewPasetoMaker(key)
+	require.NoError(t, err)
+	token, _, err := maker.CreateToken(TokenTypeAccess, "1", util.RandomEmail(), db.UserRoleUser, uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	keyring, err := NewKeyring(util.Config{TokenMaker: MakerPaseto, TokenSymmetricKey: util.RandomStr(32), TokenPreviousSymmetricKeys: []string{key}})
+	require.NoError(t, err)
+	_, err = keyring.VerifyToken(token)
+	require.NoError(t, err)
+}
@@ -0,0 +1,66 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// readKeyFile returns nil when no file is configured, a maker then has to do
+// with the other key.
+func readKeyFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read key file: %w", err)
+	}
+	return data, nil
+}
+
+// loadEd25519Keys parses a PKCS8 private key and a PKIX public key from PEM,
+// either may be missing.
+func loadEd25519Keys(privateKeyPEM, publicKeyPEM []byte) (ed25519.PrivateKey, ed25519.PublicKey, error) {
+	var privateKey ed25519.PrivateKey
+	var publicKey ed25519.PublicKey
+	if privateKeyPEM != nil {
+		key, err := jwt.ParseEdPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot parse ed25519 private key: %w", err)
+		}
+		privateKey = key.(ed25519.PrivateKey)
+	}
+	if publicKeyPEM != nil {
+		key, err := jwt.ParseEdPublicKeyFromPEM(publicKeyPEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot parse ed25519 public key: %w", err)
+		}
+		publicKey = key.(ed25519.PublicKey)
+	}
+	return privateKey, publicKey, nil
+}
+
+// loadRSAKeys parses a PKCS1 or PKCS8 private key and a PKIX public key from
+// PEM, either may be missing.
+func loadRSAKeys(privateKeyPEM, publicKeyPEM []byte) (*rsa.PrivateKey, *rsa.PublicKey, error) {
+	var privateKey *rsa.PrivateKey
+	var publicKey *rsa.PublicKey
+	var err error
+	if privateKeyPEM != nil {
+		privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot parse rsa private key: %w", err)
+		}
+	}
+	if publicKeyPEM != nil {
+		publicKey, err = jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot parse rsa public key: %w", err)
+		}
+	}
+	return privateKey, publicKey, nil
+}
@@ -1,11 +1,29 @@
 package token
 
 import (
+	"errors"
 	db "main/db/sqlc"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+// Token makers selectable with TOKEN_MAKER. The public key ones let other
+// services verify our tokens without holding a secret.
+const (
+	MakerPaseto       = "paseto"
+	MakerJWT          = "jwt"
+	MakerPasetoPublic = "paseto_public"
+	MakerJWTRS256     = "jwt_rs256"
+	MakerJWTEdDSA     = "jwt_eddsa"
+)
+
+var (
+	ErrMissingKey = errors.New("a private or a public key is required")
+	ErrVerifyOnly = errors.New("token maker only has a public key, it can't create tokens")
 )
 
 type Maker interface {
-	CreateToken(userID, email string, role db.UserRole, duration time.Duration) (string, *Payload, error)
+	CreateToken(tokenType TokenType, userID, email string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error)
 	VerifyToken(token string) (*Payload, error)
 }
@@ -7,28 +7,33 @@ import (
 
 	"golang.org/x/crypto/chacha20poly1305"
 
+	"github.com/google/uuid"
 	"github.com/o1egl/paseto"
 )
 
 type PasetoMaker struct {
 	paseto       *paseto.V2
 	symmetricKey []byte
+	keyID        string
 }
 
 func NewPasetoMaker(symmetricKey string) (Maker, error) {
+	return newPasetoMaker(symmetricKey, "")
+}
 
+func newPasetoMaker(symmetricKey, keyID string) (*PasetoMaker, error) {
 	if len(symmetricKey) != chacha20poly1305.KeySize {
 		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", chacha20poly1305.KeySize)
 	}
-	return &PasetoMaker{paseto: paseto.NewV2(), symmetricKey: []byte(symmetricKey)}, nil
+	return &PasetoMaker{paseto: paseto.NewV2(), symmetricKey: []byte(symmetricKey), keyID: keyID}, nil
 }
 
-func (maker *PasetoMaker) CreateToken(userID, email string, role db.UserRole, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(userID, email, role, duration)
+func (maker *PasetoMaker) CreateToken(tokenType TokenType, userID, email string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(tokenType, userID, email, role, sessionID, duration)
 	if err != nil {
 		return "", payload, err
 	}
-	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, newKeyFooter(maker.keyID))
 	return token, payload, err
 }
 
@@ -47,3 +52,11 @@ func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 
 	return payload, nil
 }
+
+func (maker *PasetoMaker) tokenKeyID(token string) string {
+	var footer keyFooter
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return ""
+	}
+	return footer.KeyID
+}
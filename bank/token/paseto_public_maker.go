@@ -0,0 +1,105 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	db "main/db/sqlc"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/google/uuid"
+)
+
+// PasetoPublicMaker signs v4.public tokens with an Ed25519 private key, a
+// maker made from the public key alone only verifies them.
+type PasetoPublicMaker struct {
+	secretKey *paseto.V4AsymmetricSecretKey
+	publicKey paseto.V4AsymmetricPublicKey
+	keyID     string
+}
+
+func NewPasetoPublicMaker(privateKey ed25519.PrivateKey, publicKey ed25519.PublicKey) (Maker, error) {
+	return newPasetoPublicMaker(privateKey, publicKey, "")
+}
+
+func newPasetoPublicMaker(privateKey ed25519.PrivateKey, publicKey ed25519.PublicKey, keyID string) (*PasetoPublicMaker, error) {
+	maker := &PasetoPublicMaker{keyID: keyID}
+	if privateKey != nil {
+		secretKey, err := paseto.NewV4AsymmetricSecretKeyFromBytes(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+		maker.secretKey = &secretKey
+		maker.publicKey = secretKey.Public()
+		return maker, nil
+	}
+	if publicKey == nil {
+		return nil, ErrMissingKey
+	}
+	key, err := paseto.NewV4AsymmetricPublicKeyFromBytes(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	maker.publicKey = key
+	return maker, nil
+}
+
+func (maker *PasetoPublicMaker) CreateToken(tokenType TokenType, userID, email string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	if maker.secretKey == nil {
+		return "", nil, ErrVerifyOnly
+	}
+	payload, err := NewPayload(tokenType, userID, email, role, sessionID, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	claims, err := json.Marshal(payload)
+	if err != nil {
+		return "", payload, err
+	}
+	var footer []byte
+	if maker.keyID != "" {
+		footer, err = json.Marshal(keyFooter{KeyID: maker.keyID})
+		if err != nil {
+			return "", payload, err
+		}
+	}
+	pasetoToken, err := paseto.NewTokenFromClaimsJSON(claims, footer)
+	if err != nil {
+		return "", payload, err
+	}
+	return pasetoToken.V4Sign(*maker.secretKey, nil), payload, nil
+}
+
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	// the expiry is checked on expired_at by payload.Valid
+	parser := paseto.NewParserWithoutExpiryCheck()
+	pasetoToken, err := parser.ParseV4Public(maker.publicKey, token, nil)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal(pasetoToken.ClaimsJSON(), payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func (maker *PasetoPublicMaker) tokenKeyID(token string) string {
+	data, err := paseto.NewParser().UnsafeParseFooter(paseto.V4Public, token)
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	var footer keyFooter
+	if err := json.Unmarshal(data, &footer); err != nil {
+		return ""
+	}
+	return footer.KeyID
+}
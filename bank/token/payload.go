@@ -3,6 +3,7 @@ package token
 import (
 	"errors"
 	db "main/db/sqlc"
+	"slices"
 	"strconv"
 	"time"
 
@@ -10,20 +11,36 @@ import (
 )
 
 var (
-	ErrInvalidToken = errors.New("token is invalid")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken   = errors.New("token is invalid")
+	ErrExpiredToken   = errors.New("token has expired")
+	ErrWrongTokenType = errors.New("token has the wrong type")
+)
+
+// TokenType keeps the long-lived refresh token from being used as an access
+// token, both are issued for the same session.
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
 )
 
 type Payload struct {
 	ID        uuid.UUID   `json:"id"`
+	Type      TokenType   `json:"token_type"`
+	SessionID uuid.UUID   `json:"session_id"`
 	UserID    int         `json:"user_id"`
 	Email     string      `json:"email"`
 	Role      db.UserRole `json:"role"`
 	IssuedAt  time.Time   `json:"issued_at"`
 	ExpiredAt time.Time   `json:"expired_at"`
+	// APIKeyID and Permissions are only set when the caller authenticated
+	// with an API key, which can only do what its permissions allow.
+	APIKeyID    int64    `json:"api_key_id,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 }
 
-func NewPayload(userID, email string, role db.UserRole, duration time.Duration) (*Payload, error) {
+func NewPayload(tokenType TokenType, userID, email string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -35,6 +52,8 @@ func NewPayload(userID, email string, role db.UserRole, duration time.Duration)
 
 	payload := &Payload{
 		ID:        tokenID,
+		Type:      tokenType,
+		SessionID: sessionID,
 		UserID:    id,
 		Email:     email,
 		Role:      role,
@@ -49,3 +68,12 @@ func (payload *Payload) Valid() error {
 	}
 	return nil
 }
+
+// HasPermission always holds for user sessions, they're only limited by the
+// role of the user.
+func (payload *Payload) HasPermission(permission string) bool {
+	if payload.APIKeyID == 0 {
+		return true
+	}
+	return slices.Contains(payload.Permissions, permission)
+}
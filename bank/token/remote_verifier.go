@@ -0,0 +1,195 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// minRefreshInterval bounds how often tokens naming an unknown key id make
+// the verifier fetch the JWKS again.
+const minRefreshInterval = 10 * time.Second
+
+// staleKeysGrace is how long past ttl the verifier keeps trusting the last
+// keys it fetched while the JWKS can't be fetched again.
+const staleKeysGrace = 5 * time.Minute
+
+var errStaleKeys = errors.New("jwks keys are stale and cannot be fetched again")
+
+// RemoteVerifier verifies our tokens in other services from the keys
+// published at /.well-known/jwks.json. The JWKS is cached for ttl and fetched
+// again early when a token names a key that isn't in it yet, which happens
+// right after a rotation. While the JWKS can't be fetched the last keys are
+// trusted for staleKeysGrace past ttl, then tokens are rejected.
+type RemoteVerifier struct {
+	jwksURL string
+	client  *http.Client
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]keyedMaker
+	fetchedAt   time.Time
+	lastAttempt time.Time
+	fetching    *jwksFetch
+}
+
+// jwksFetch is a fetch of the JWKS in flight, callers that need the keys
+// while it runs wait for its result instead of fetching again.
+type jwksFetch struct {
+	done chan struct{}
+	keys map[string]keyedMaker
+	err  error
+}
+
+func NewRemoteVerifier(jwksURL string, ttl time.Duration) *RemoteVerifier {
+	return &RemoteVerifier{
+		jwksURL: jwksURL,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// CreateToken always fails, a RemoteVerifier can stand in for a Maker in
+// code that only verifies tokens.
+func (verifier *RemoteVerifier) CreateToken(tokenType TokenType, userID, email string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	return "", nil, ErrVerifyOnly
+}
+
+func (verifier *RemoteVerifier) VerifyToken(token string) (*Payload, error) {
+	keyID := remoteTokenKeyID(token)
+	keys, err := verifier.getKeys(context.Background(), keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyID == "" {
+		for _, maker := range keys {
+			payload, err := maker.VerifyToken(token)
+			if err != ErrInvalidToken {
+				return payload, err
+			}
+		}
+		return nil, ErrInvalidToken
+	}
+	maker, ok := keys[keyID]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return maker.VerifyToken(token)
+}
+
+// getKeys returns the cached keys, fetching them when the cache expired or
+// doesn't have keyID. The fetch runs outside the lock, so tokens of known keys
+// keep verifying while it waits on the issuer.
+func (verifier *RemoteVerifier) getKeys(ctx context.Context, keyID string) (map[string]keyedMaker, error) {
+	verifier.mu.Lock()
+	now := verifier.now()
+	_, known := verifier.keys[keyID]
+	fresh := verifier.keys != nil && now.Sub(verifier.fetchedAt) < verifier.ttl
+	if fresh && (known || keyID == "") {
+		keys := verifier.keys
+		verifier.mu.Unlock()
+		return keys, nil
+	}
+	if verifier.keys != nil && verifier.fetching == nil && now.Sub(verifier.lastAttempt) < minRefreshInterval {
+		keys, ok := verifier.usableKeys(now)
+		verifier.mu.Unlock()
+		if !ok {
+			return nil, errStaleKeys
+		}
+		return keys, nil
+	}
+
+	call := verifier.fetching
+	if call != nil {
+		verifier.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.keys, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call = &jwksFetch{done: make(chan struct{})}
+	verifier.fetching = call
+	verifier.lastAttempt = now
+	verifier.mu.Unlock()
+
+	keys, err := verifier.fetch(ctx)
+
+	verifier.mu.Lock()
+	if err == nil {
+		verifier.keys = keys
+		verifier.fetchedAt = now
+		call.keys = keys
+	} else if keys, ok := verifier.usableKeys(verifier.now()); ok {
+		// keep verifying with the last keys for a while when the issuer is
+		// unreachable
+		call.keys = keys
+	} else {
+		call.err = err
+	}
+	verifier.fetching = nil
+	verifier.mu.Unlock()
+	close(call.done)
+	return call.keys, call.err
+}
+
+// usableKeys returns the cached keys unless they're older than ttl plus the
+// grace period. The caller must hold verifier.mu.
+func (verifier *RemoteVerifier) usableKeys(now time.Time) (map[string]keyedMaker, bool) {
+	if verifier.keys == nil || now.Sub(verifier.fetchedAt) >= verifier.ttl+staleKeysGrace {
+		return nil, false
+	}
+	return verifier.keys, true
+}
+
+func (verifier *RemoteVerifier) fetch(ctx context.Context) (map[string]keyedMaker, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, verifier.jwksURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create jwks request: %w", err)
+	}
+	res, err := verifier.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch jwks: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot fetch jwks: unexpected status %d", res.StatusCode)
+	}
+
+	var set JSONWebKeySet
+	if err := json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("cannot decode jwks: %w", err)
+	}
+	keys := make(map[string]keyedMaker, len(set.Keys))
+	for _, jwk := range set.Keys {
+		maker, err := parseJSONWebKey(jwk)
+		if err != nil {
+			return nil, err
+		}
+		if maker != nil {
+			keys[jwk.Kid] = maker
+		}
+	}
+	return keys, nil
+}
+
+// remoteTokenKeyID reads the key id of a v4.public PASETO or of a JWT, the
+// only kinds of tokens with a published key.
+func remoteTokenKeyID(token string) string {
+	if strings.HasPrefix(token, "v4.public.") {
+		return (&PasetoPublicMaker{}).tokenKeyID(token)
+	}
+	return jwtKeyID(token)
+}
@@ -3,21 +3,42 @@ package util
 import (
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	DbDriver             string        `mapstructure:"DBDRIVER"`
-	DbSource             string        `mapstructure:"DBSOURCE"`
-	APIEndpoint          string        `mapstructure:"API_ENDPOINT"`
-	GrpcAPIEndpoint      string        `mapstructure:"GRPC_API_ENDPOINT"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	RedisAddress         string        `mapstructure:"REDIS_SERVER_ADDRESS"`
-	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
-	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
-	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
-	TokenDuration        time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	DbDriver                    string        `mapstructure:"DBDRIVER"`
+	DbSource                    string        `mapstructure:"DBSOURCE"`
+	APIEndpoint                 string        `mapstructure:"API_ENDPOINT"`
+	GrpcAPIEndpoint             string        `mapstructure:"GRPC_API_ENDPOINT"`
+	TokenSymmetricKey           string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	TokenMaker                  string        `mapstructure:"TOKEN_MAKER"`
+	TokenPrivateKeyFile         string        `mapstructure:"TOKEN_PRIVATE_KEY_FILE"`
+	TokenPublicKeyFile          string        `mapstructure:"TOKEN_PUBLIC_KEY_FILE"`
+	TokenPreviousSymmetricKeys  []string      `mapstructure:"TOKEN_PREVIOUS_SYMMETRIC_KEYS"`
+	TokenPreviousPublicKeyFiles []string      `mapstructure:"TOKEN_PREVIOUS_PUBLIC_KEY_FILES"`
+	JWKSMaxAge                  time.Duration `mapstructure:"JWKS_MAX_AGE"`
+	OIDCIssuerURL               string        `mapstructure:"OIDC_ISSUER_URL"`
+	OIDCClientID                string        `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret            string        `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL             string        `mapstructure:"OIDC_REDIRECT_URL"`
+	RedisAddress                string        `mapstructure:"REDIS_SERVER_ADDRESS"`
+	EmailSenderName             string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress          string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	EmailSenderPassword         string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	TokenDuration               time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration        time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	IdempotencyKeyTTL           time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	ExchangeRateFile            string        `mapstructure:"EXCHANGE_RATE_FILE"`
+	VerifyEmailInterval         time.Duration `mapstructure:"VERIFY_EMAIL_RESEND_INTERVAL"`
+	LoginMaxAttempts            int32         `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginMaxAttemptsPerIP       int32         `mapstructure:"LOGIN_MAX_ATTEMPTS_PER_IP"`
+	LoginBackoffBase            time.Duration `mapstructure:"LOGIN_BACKOFF_BASE"`
+	LoginLockoutDuration        time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	// TrustedProxies are the CIDRs of the reverse proxies in front of the
+	// servers, the client ip is only read from x-forwarded-for past them
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -25,6 +46,15 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigName(".env")
 	viper.SetConfigType("env")
 
+	viper.SetDefault("TOKEN_MAKER", "paseto")
+	viper.SetDefault("JWKS_MAX_AGE", 5*time.Minute)
+	viper.SetDefault("IDEMPOTENCY_KEY_TTL", 24*time.Hour)
+	viper.SetDefault("VERIFY_EMAIL_RESEND_INTERVAL", time.Minute)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS_PER_IP", 20)
+	viper.SetDefault("LOGIN_BACKOFF_BASE", time.Second)
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", 15*time.Minute)
+
 	viper.AutomaticEnv()
 	err = viper.ReadInConfig()
 	if err != nil {
@@ -34,3 +64,15 @@ func LoadConfig(path string) (config Config, err error) {
 	err = viper.Unmarshal(&config)
 	return
 }
+
+// WatchConfig calls onChange with the config reloaded every time the .env
+// file loaded by LoadConfig is written. Environment variables still override
+// the file, changing them needs a restart.
+func WatchConfig(onChange func(config Config, err error)) {
+	viper.OnConfigChange(func(event fsnotify.Event) {
+		var config Config
+		err := viper.Unmarshal(&config)
+		onChange(config, err)
+	})
+	viper.WatchConfig()
+}
@@ -16,3 +16,13 @@ func IsSupportedCurrency(currency string) bool {
 	}
 	return false
 }
+
+// CurrencyMinorUnits returns the number of decimal places of the currency's
+// minor unit, amounts are stored as integers in that unit
+func CurrencyMinorUnits(currency string) int {
+	switch CurrencyType(currency) {
+	case VND:
+		return 0
+	}
+	return 2
+}
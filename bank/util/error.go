@@ -7,10 +7,17 @@ import (
 )
 
 const (
-	ErrorValidationFailed = "ERROR_01"
-	ErrorNotFound         = "ERROR_02"
-	ErrorInternal         = "ERROR_03" // Add internal error code
-	ErrorBadRequest       = "ERROR_04" // Add internal error code
+	ErrorValidationFailed  = "ERROR_01"
+	ErrorNotFound          = "ERROR_02"
+	ErrorInternal          = "ERROR_03" // Add internal error code
+	ErrorBadRequest        = "ERROR_04" // Add internal error code
+	ErrorConflict          = "ERROR_05"
+	ErrorInsufficientFunds = "ERROR_06"
+	ErrorEmailNotVerified  = "ERROR_07"
+	ErrorTooManyRequests   = "ERROR_08"
+	ErrorAccountFrozen     = "ERROR_09"
+	ErrorAccountClosed     = "ERROR_10"
+	ErrorAccountNotEmpty   = "ERROR_11"
 )
 
 func HasContextError(ctx *gin.Context) bool {
@@ -65,3 +72,59 @@ func NewBadRequestError(err error, message string) *CustomError {
 		ErrCode: ErrorBadRequest,
 	}
 }
+func NewConflictError(err error, message string) *CustomError {
+	return &CustomError{
+		Err:     err,
+		Status:  http.StatusConflict,
+		Message: message,
+		ErrCode: ErrorConflict,
+	}
+}
+func NewInsufficientFundsError(err error, message string) *CustomError {
+	return &CustomError{
+		Err:     err,
+		Status:  http.StatusUnprocessableEntity,
+		Message: message,
+		ErrCode: ErrorInsufficientFunds,
+	}
+}
+func NewEmailNotVerifiedError(err error, message string) *CustomError {
+	return &CustomError{
+		Err:     err,
+		Status:  http.StatusForbidden,
+		Message: message,
+		ErrCode: ErrorEmailNotVerified,
+	}
+}
+func NewTooManyRequestsError(err error, message string) *CustomError {
+	return &CustomError{
+		Err:     err,
+		Status:  http.StatusTooManyRequests,
+		Message: message,
+		ErrCode: ErrorTooManyRequests,
+	}
+}
+func NewAccountFrozenError(err error, message string) *CustomError {
+	return &CustomError{
+		Err:     err,
+		Status:  http.StatusUnprocessableEntity,
+		Message: message,
+		ErrCode: ErrorAccountFrozen,
+	}
+}
+func NewAccountClosedError(err error, message string) *CustomError {
+	return &CustomError{
+		Err:     err,
+		Status:  http.StatusUnprocessableEntity,
+		Message: message,
+		ErrCode: ErrorAccountClosed,
+	}
+}
+func NewAccountNotEmptyError(err error, message string) *CustomError {
+	return &CustomError{
+		Err:     err,
+		Status:  http.StatusUnprocessableEntity,
+		Message: message,
+		ErrCode: ErrorAccountNotEmpty,
+	}
+}
@@ -2,6 +2,9 @@ package util
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strings"
 
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
@@ -11,11 +14,13 @@ const (
 	GrpcGatewayAgent = "grpcgateway-user-agent"
 	UserAgent        = "user-agent"
 	XForwardFor      = "x-forwarded-for"
+	IdempotencyKey   = "idempotency-key"
 )
 
 type Metadata struct {
-	ClientIp  string
-	UserAgent string
+	ClientIp       string
+	UserAgent      string
+	IdempotencyKey string
 }
 
 func ExtractMetadata(ctx context.Context) *Metadata {
@@ -24,16 +29,78 @@ func ExtractMetadata(ctx context.Context) *Metadata {
 		if userAgents := md.Get(GrpcGatewayAgent); len(userAgents) > 0 {
 			mtdt.UserAgent = userAgents[0]
 		}
-		if userIps := md.Get(XForwardFor); len(userIps) > 0 {
-			mtdt.ClientIp = userIps[0]
-		}
+		mtdt.ClientIp = clientIPFromForwardedFor(md.Get(XForwardFor))
 
 		if userAgents := md.Get(UserAgent); len(userAgents) > 0 {
 			mtdt.UserAgent = userAgents[0]
 		}
+		if keys := md.Get(IdempotencyKey); len(keys) > 0 {
+			mtdt.IdempotencyKey = keys[0]
+		}
 	}
 	if p, ok := peer.FromContext(ctx); ok {
 		mtdt.ClientIp = p.Addr.String()
 	}
 	return mtdt
 }
+
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies sets the networks whose x-forwarded-for entries are
+// believed, the gateway's own entry for the connection is always believed.
+func SetTrustedProxies(cidrs []string) error {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+	trustedProxies = networks
+	return nil
+}
+
+// clientIPFromForwardedFor picks the client out of the x-forwarded-for hops.
+// The gateway appends the address of the connection last, everything before
+// it was sent by the client and is only believed when the hop after it is a
+// trusted proxy.
+func clientIPFromForwardedFor(values []string) string {
+	var hops []string
+	for _, value := range values {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i > 0; i-- {
+		if !isTrustedProxy(hops[i]) {
+			return hops[i]
+		}
+	}
+	if len(hops) > 0 {
+		return hops[0]
+	}
+	return ""
+}
+
+func isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestExtractMetadataClientIP(t *testing.T) {
+	testCases := []struct {
+		name           string
+		forwardedFor   string
+		trustedProxies []string
+		want           string
+	}{
+		{name: "Direct", forwardedFor: "203.0.113.7", want: "203.0.113.7"},
+		// the gateway appends the connection address to the client's header
+		{name: "SpoofedHeader", forwardedFor: "198.51.100.1, 203.0.113.7", want: "203.0.113.7"},
+		{name: "TrustedProxy", forwardedFor: "198.51.100.1, 10.0.0.2", trustedProxies: []string{"10.0.0.0/8"}, want: "198.51.100.1"},
+		{name: "SpoofedBehindProxy", forwardedFor: "192.0.2.9, 198.51.100.1, 10.0.0.2", trustedProxies: []string{"10.0.0.0/8"}, want: "198.51.100.1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, SetTrustedProxies(tc.trustedProxies))
+			t.Cleanup(func() { SetTrustedProxies(nil) })
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(XForwardFor, tc.forwardedFor))
+			require.Equal(t, tc.want, ExtractMetadata(ctx).ClientIp)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"sync"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -19,3 +20,18 @@ func HashPassword(password string) (string, error) {
 func VerifyPassword(password string, hashedPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
+
+var (
+	dummyPasswordHash     []byte
+	dummyPasswordHashOnce sync.Once
+)
+
+// VerifyDummyPassword spends the time of a VerifyPassword when there is no
+// hash to compare with, so a login for an unknown email takes as long as one
+// with a wrong password.
+func VerifyDummyPassword(password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte(RandomStr(16)), bcrypt.DefaultCost)
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+}
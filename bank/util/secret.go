@@ -0,0 +1,26 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// NewSecretToken returns a url safe token made of n bytes from crypto/rand,
+// unlike RandomStr it's fit for secrets sent to users.
+func NewSecretToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashSecretToken returns the hex sha256 of a token made by NewSecretToken,
+// it's what gets stored so a leaked table doesn't leak usable tokens.
+func HashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
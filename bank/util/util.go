@@ -1,6 +1,9 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -32,3 +35,13 @@ func GetPaginateFromRequest(ctx *gin.Context, defaultPage string, defaultLimit s
 	}
 	return int32(page), int32(limit)
 }
+
+// HashRequest returns a hex encoded SHA-256 fingerprint of the JSON form of body
+func HashRequest(body any) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pkg/log"
+	"main/pkg/statement"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TaskSendAccountStatement = "task:send_account_statement"
+)
+
+type PayloadSendAccountStatement struct {
+	AccountID int64     `json:"account_id"`
+	Format    string    `json:"format"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendAccountStatement(ctx context.Context, payload *PayloadSendAccountStatement, opt ...asynq.Option) error {
+	jsonMarshal, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	task := asynq.NewTask(TaskSendAccountStatement, jsonMarshal, opt...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	fields := logrus.Fields{
+		"type":      task.Type(),
+		"queue":     info.Queue,
+		"max_retry": info.MaxRetry,
+	}
+	log.Logger.WithFields(fields).Info("enqueued task")
+	return nil
+}
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountStatement(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendAccountStatement
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	format, err := statement.ParseFormat(payload.Format)
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	result, err := processor.store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: payload.AccountID,
+		FromTime:  payload.FromTime,
+		ToTime:    payload.ToTime,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("account doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get account statement: %w", err)
+	}
+	user, err := processor.store.GetUser(ctx, result.Account.Owner)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	accountStatement := statement.New(result, payload.FromTime, payload.ToTime)
+	var buf bytes.Buffer
+	if err := statement.Render(&buf, accountStatement, format); err != nil {
+		return fmt.Errorf("failed to render statement: %w", err)
+	}
+	// the mailer attaches files from disk and names them after the file
+	dir, err := os.MkdirTemp("", "statement")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	attachment := filepath.Join(dir, statement.FileName(accountStatement, format))
+	if err := os.WriteFile(attachment, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+
+	subject := fmt.Sprintf("Your statement for account %d", accountStatement.AccountID)
+	content := fmt.Sprintf(`Hello %s,<br/>
+	Please find attached the statement of account %d from %s to %s (UTC).<br/>
+	`, user.FullName, accountStatement.AccountID, payload.FromTime.UTC().Format(time.DateOnly), payload.ToTime.UTC().Format(time.DateOnly))
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, []string{attachment})
+	if err != nil {
+		return fmt.Errorf("failed to send account statement: %w", err)
+	}
+	fields := logrus.Fields{
+		"type":       task.Type(),
+		"account_id": accountStatement.AccountID,
+		"email":      user.Email,
+	}
+	log.Logger.WithFields(fields).Info("processed task")
+	return nil
+}
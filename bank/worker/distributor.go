@@ -8,6 +8,10 @@ import (
 
 type TaskDistributor interface {
 	DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opt ...asynq.Option) error
+	DistributeTaskSendEmailChangeNotice(ctx context.Context, payload *PayloadSendEmailChangeNotice, opt ...asynq.Option) error
+	DistributeTaskSendPasswordReset(ctx context.Context, payload *PayloadSendPasswordReset, opt ...asynq.Option) error
+	DistributeTaskSendLoginLockoutNotice(ctx context.Context, payload *PayloadSendLoginLockoutNotice, opt ...asynq.Option) error
+	DistributeTaskSendAccountStatement(ctx context.Context, payload *PayloadSendAccountStatement, opt ...asynq.Option) error
 }
 
 type RedisTaskDistributor struct {
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"main/pkg/log"
+
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TaskSendEmailChangeNotice = "task:send_email_change_notice"
+)
+
+type PayloadSendEmailChangeNotice struct {
+	UserID   int64  `json:"user_id"`
+	OldEmail string `json:"old_email"`
+	NewEmail string `json:"new_email"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendEmailChangeNotice(ctx context.Context, payload *PayloadSendEmailChangeNotice, opt ...asynq.Option) error {
+	jsonMarshal, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	task := asynq.NewTask(TaskSendEmailChangeNotice, jsonMarshal, opt...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	fields := logrus.Fields{
+		"type":      task.Type(),
+		"queue":     info.Queue,
+		"max_retry": info.MaxRetry,
+	}
+	log.Logger.WithFields(fields).Info("enqueued task")
+	return nil
+}
+
+// ProcessTaskSendEmailChangeNotice tells the old address that a change of
+// email was requested, so the owner notices if it wasn't them.
+func (processor *RedisTaskProcessor) ProcessTaskSendEmailChangeNotice(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendEmailChangeNotice
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Your Simple Bank email is being changed"
+	content := fmt.Sprintf(`Hello %s,<br/>
+	A change of the email address of your Simple Bank account to %s was requested.<br/>
+	It takes effect once the new address is confirmed. If you didn't ask for it, please change your password right away.<br/>
+	`, user.FullName, payload.NewEmail)
+	to := []string{payload.OldEmail}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email change notice: %w", err)
+	}
+	fields := logrus.Fields{
+		"type":  task.Type(),
+		"email": payload.OldEmail,
+	}
+	log.Logger.WithFields(fields).Info("processed task")
+	return nil
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"main/pkg/log"
+
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TaskSendLoginLockoutNotice = "task:send_login_lockout_notice"
+)
+
+type PayloadSendLoginLockoutNotice struct {
+	UserID      int64  `json:"user_id"`
+	ClientIp    string `json:"client_ip"`
+	LockedUntil string `json:"locked_until"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendLoginLockoutNotice(ctx context.Context, payload *PayloadSendLoginLockoutNotice, opt ...asynq.Option) error {
+	jsonMarshal, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	task := asynq.NewTask(TaskSendLoginLockoutNotice, jsonMarshal, opt...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	fields := logrus.Fields{
+		"type":      task.Type(),
+		"queue":     info.Queue,
+		"max_retry": info.MaxRetry,
+	}
+	log.Logger.WithFields(fields).Info("enqueued task")
+	return nil
+}
+
+// ProcessTaskSendLoginLockoutNotice tells the user that logins to their
+// account were locked after too many wrong passwords.
+func (processor *RedisTaskProcessor) ProcessTaskSendLoginLockoutNotice(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendLoginLockoutNotice
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Too many failed logins to your Simple Bank account"
+	content := fmt.Sprintf(`Hello %s,<br/>
+	Logins to your Simple Bank account were locked until %s after too many wrong passwords, the last one from %s.<br/>
+	If it wasn't you, please reset your password once the lock is over.<br/>
+	`, user.FullName, payload.LockedUntil, payload.ClientIp)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send login lockout notice: %w", err)
+	}
+	fields := logrus.Fields{
+		"type":  task.Type(),
+		"email": user.Email,
+	}
+	log.Logger.WithFields(fields).Info("processed task")
+	return nil
+}
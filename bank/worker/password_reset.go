@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pkg/log"
+	"main/util"
+
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TaskSendPasswordReset = "task:send_password_reset"
+)
+
+type PayloadSendPasswordReset struct {
+	Email string `json:"email"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPasswordReset(ctx context.Context, payload *PayloadSendPasswordReset, opt ...asynq.Option) error {
+	jsonMarshal, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	task := asynq.NewTask(TaskSendPasswordReset, jsonMarshal, opt...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	fields := logrus.Fields{
+		"type":      task.Type(),
+		"queue":     info.Queue,
+		"max_retry": info.MaxRetry,
+	}
+	log.Logger.WithFields(fields).Info("enqueued task")
+	return nil
+}
+
+// ProcessTaskSendPasswordReset emails a single use password reset token, only
+// its hash is kept in the database. Unknown emails are dropped silently so
+// the endpoint doesn't tell which emails are registered.
+func (processor *RedisTaskProcessor) ProcessTaskSendPasswordReset(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPasswordReset
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUserByEmail(ctx, payload.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	resetToken, err := util.NewSecretToken(32)
+	if err != nil {
+		return err
+	}
+	passwordReset, err := processor.store.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		UserID:    user.UserID,
+		TokenHash: util.HashSecretToken(resetToken),
+	})
+	if err != nil {
+		return fmt.Errorf("create password reset failed: %w", err)
+	}
+
+	subject := "Reset your Simple Bank password"
+	resetUrl := fmt.Sprintf("http://simple-bank.org/reset-password?token=%s", resetToken)
+	content := fmt.Sprintf(`Hello %s,<br/>
+	We received a request to reset your password.<br/>
+	Please <a href="%s">click here</a> to choose a new one, the link expires at %s.<br/>
+	If you didn't ask for it, you can ignore this email.<br/>
+	`, user.FullName, resetUrl, passwordReset.ExpiredAt.Format("2006-01-02 15:04 MST"))
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+	fields := logrus.Fields{
+		"type":  task.Type(),
+		"email": user.Email,
+	}
+	log.Logger.WithFields(fields).Info("processed task")
+	return nil
+}
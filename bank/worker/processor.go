@@ -11,6 +11,11 @@ import (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendEmailChangeNotice(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendPasswordReset(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendLoginLockoutNotice(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendAccountStatement(ctx context.Context, task *asynq.Task) error
+	ProcessTaskProcessScheduledTransfers(ctx context.Context, task *asynq.Task) error
 }
 type RedisTaskProcessor struct {
 	server *asynq.Server
@@ -34,6 +39,11 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(TaskVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskSendEmailChangeNotice, processor.ProcessTaskSendEmailChangeNotice)
+	mux.HandleFunc(TaskSendPasswordReset, processor.ProcessTaskSendPasswordReset)
+	mux.HandleFunc(TaskSendLoginLockoutNotice, processor.ProcessTaskSendLoginLockoutNotice)
+	mux.HandleFunc(TaskSendAccountStatement, processor.ProcessTaskSendAccountStatement)
+	mux.HandleFunc(TaskProcessScheduledTransfers, processor.ProcessTaskProcessScheduledTransfers)
 
 	return processor.server.Start(mux)
 }
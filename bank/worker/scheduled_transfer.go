@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "main/db/sqlc"
+	"main/pkg/log"
+	"main/pkg/schedule"
+	"main/util"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TaskProcessScheduledTransfers = "task:process_scheduled_transfers"
+
+	// maxScheduledTransferFailures disables a schedule after that many runs
+	// in a row failed for insufficient funds.
+	maxScheduledTransferFailures = 3
+	// maxScheduledTransferRetries fails an attempt after that many tries
+	// failed for other reasons.
+	maxScheduledTransferRetries = 5
+	scheduledTransferBatchSize  = 100
+	scheduledTransferKeyWindow  = 7 * 24 * time.Hour
+)
+
+// ProcessTaskProcessScheduledTransfers claims the due runs of every active
+// schedule and executes the pending attempts, including ones left over by an
+// earlier tick. Each run moves money at most once since it is executed under
+// an idempotency key derived from the schedule and the run time.
+func (processor *RedisTaskProcessor) ProcessTaskProcessScheduledTransfers(ctx context.Context, task *asynq.Task) error {
+	claimed, err := processor.store.ClaimScheduledTransfersTx(ctx, db.ClaimScheduledTransfersTxParams{
+		Now:   time.Now(),
+		Limit: scheduledTransferBatchSize,
+		NextRunAt: func(scheduledTransfer db.ScheduledTransfer) (time.Time, error) {
+			runSchedule, err := schedule.Parse(scheduledTransfer.CronExpression.String, scheduledTransfer.IntervalSeconds.Int64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("scheduled transfer %d: %w", scheduledTransfer.ID, err)
+			}
+			return runSchedule.Next(scheduledTransfer.NextRunAt, time.Now()), nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to claim scheduled transfers: %w", err)
+	}
+
+	attempts, err := processor.store.ListPendingScheduledTransferAttempts(ctx, scheduledTransferBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list pending scheduled transfer attempts: %w", err)
+	}
+	for _, attempt := range attempts {
+		if err := processor.runScheduledTransfer(ctx, attempt); err != nil {
+			log.Logger.WithFields(logrus.Fields{
+				"type":                  task.Type(),
+				"scheduled_transfer_id": attempt.ScheduledTransferID,
+				"attempt_id":            attempt.ID,
+			}).Error(err)
+		}
+	}
+
+	fields := logrus.Fields{
+		"type":    task.Type(),
+		"claimed": len(claimed),
+		"pending": len(attempts),
+	}
+	log.Logger.WithFields(fields).Info("processed task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) runScheduledTransfer(ctx context.Context, attempt db.ListPendingScheduledTransferAttemptsRow) error {
+	arg := db.CompleteScheduledTransferAttemptTxParams{
+		CompleteScheduledTransferAttemptParams: db.CompleteScheduledTransferAttemptParams{
+			ID: attempt.ID,
+		},
+		ScheduledTransferID: attempt.ScheduledTransferID,
+		MaxFailures:         maxScheduledTransferFailures,
+	}
+
+	if attempt.ScheduleStatus != db.ScheduledTransferStatusActive {
+		arg.Status = db.ScheduledTransferAttemptStatusSkipped
+		arg.FailureReason = fmt.Sprintf("scheduled transfer is %s", attempt.ScheduleStatus)
+		return processor.completeScheduledTransferAttempt(ctx, arg)
+	}
+
+	transferArg := db.TransferTxParams{
+		FromAccountId: attempt.FromAccountID,
+		ToAccountId:   attempt.ToAccountID,
+		Amount:        attempt.Amount,
+	}
+	requestHash, err := util.HashRequest(transferArg)
+	if err != nil {
+		return err
+	}
+	result, err := processor.store.IdempotentTransferTx(ctx, db.IdempotentTransferTxParams{
+		TransferTxParams: transferArg,
+		UserID:           attempt.Owner,
+		IdempotencyKey:   fmt.Sprintf("scheduled-transfer-%d-%d", attempt.ScheduledTransferID, attempt.ScheduledFor.Unix()),
+		RequestHash:      requestHash,
+		Window:           scheduledTransferKeyWindow,
+	})
+	switch {
+	case err == nil:
+		arg.Status = db.ScheduledTransferAttemptStatusSucceeded
+		arg.TransferID = sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+	case errors.Is(err, db.ErrInsufficientFunds):
+		arg.Status = db.ScheduledTransferAttemptStatusFailed
+		arg.FailureReason = err.Error()
+		arg.InsufficientFunds = true
+	case errors.Is(err, db.ErrAccountFrozen), errors.Is(err, db.ErrAccountClosed):
+		arg.Status = db.ScheduledTransferAttemptStatusFailed
+		arg.FailureReason = err.Error()
+	case attempt.Retries+1 >= maxScheduledTransferRetries:
+		arg.Status = db.ScheduledTransferAttemptStatusFailed
+		arg.FailureReason = err.Error()
+	default:
+		// left pending for the next tick
+		retryErr := processor.store.RetryScheduledTransferAttempt(ctx, db.RetryScheduledTransferAttemptParams{
+			ID:            attempt.ID,
+			FailureReason: err.Error(),
+		})
+		if retryErr != nil {
+			return fmt.Errorf("failed to record retry: %w", retryErr)
+		}
+		return fmt.Errorf("scheduled transfer failed: %w", err)
+	}
+	return processor.completeScheduledTransferAttempt(ctx, arg)
+}
+
+func (processor *RedisTaskProcessor) completeScheduledTransferAttempt(ctx context.Context, arg db.CompleteScheduledTransferAttemptTxParams) error {
+	_, err := processor.store.CompleteScheduledTransferAttemptTx(ctx, arg)
+	if err != nil && err != sql.ErrNoRows {
+		// sql.ErrNoRows means another worker already completed the attempt
+		return fmt.Errorf("failed to complete attempt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// NewTaskScheduler enqueues the periodic tasks handled by the task processor.
+func NewTaskScheduler(redisOpt asynq.RedisClientOpt) (*asynq.Scheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, nil)
+
+	_, err := scheduler.Register("@every 1m", asynq.NewTask(TaskProcessScheduledTransfers, nil),
+		asynq.MaxRetry(0),
+		asynq.Unique(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register %s: %w", TaskProcessScheduledTransfers, err)
+	}
+	return scheduler, nil
+}
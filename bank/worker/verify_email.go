@@ -17,8 +17,11 @@ const (
 	TaskVerifyEmail = "task:send_verify_email"
 )
 
+// PayloadSendVerifyEmail verifies the email of the user, or their pending
+// email when Email is set to it.
 type PayloadSendVerifyEmail struct {
-	UserID int64 `json:"user_id"`
+	UserID int64  `json:"user_id"`
+	Email  string `json:"email,omitempty"`
 }
 
 func (distributor *RedisTaskDistributor) DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opt ...asynq.Option) error {
@@ -56,21 +59,35 @@ func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Cont
 
 	}
 
+	email := user.Email
+	if payload.Email != "" {
+		if !user.PendingEmail.Valid || user.PendingEmail.String != payload.Email {
+			return fmt.Errorf("pending email was changed: %w", asynq.SkipRetry)
+		}
+		email = payload.Email
+	}
+
 	emailVerify, err := processor.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
 		UserID:     user.UserID,
-		Email:      user.Email,
+		Email:      email,
 		SecretCode: util.RandomStr(32),
 	})
 	if err != nil {
 		return fmt.Errorf("create email verify failed: %w", err)
 	}
+	verifyUrl := fmt.Sprintf("http://simple-bank.org/v1/verify_email?id=%d&secret_code=%s", emailVerify.ID, emailVerify.SecretCode)
 	subject := "Welcome to Simple Bank"
-	verifyUrl := fmt.Sprintf("http://simple-bank.org/verify-email?id=%d&secret_code=%s", emailVerify.ID, emailVerify.SecretCode)
 	content := fmt.Sprintf(`Hello %s,<br/>
 	Thank you for registering with us!<br/>
 	Please <a href="%s">click here</a> to verify your email address.<br/>
 	`, user.FullName, verifyUrl)
-	to := []string{user.Email}
+	if payload.Email != "" {
+		subject = "Confirm your new email address"
+		content = fmt.Sprintf(`Hello %s,<br/>
+	Please <a href="%s">click here</a> to confirm %s as the new email address of your Simple Bank account.<br/>
+	`, user.FullName, verifyUrl, email)
+	}
+	to := []string{email}
 
 	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
 	if err != nil {
@@ -78,7 +95,7 @@ func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Cont
 	}
 	fields := logrus.Fields{
 		"type":  task.Type(),
-		"email": user.Email,
+		"email": email,
 	}
 	log.Logger.WithFields(fields).Info("processed task")
 	return nil